@@ -0,0 +1,314 @@
+package iter
+
+import "fmt"
+
+// The Iterables below are the counterparts of IterStrings for the other
+// common []T. They are not thread-safe.
+
+// IterInts implements Iterable API for []int.
+type IterInts struct {
+	idx  int
+	data []int
+}
+
+// NewIterInts creates a new empty IterInts struct.
+func NewIterInts() *IterInts {
+	return &IterInts{idx: -1}
+}
+
+// FromInts creates a new IterInts from a []int.
+func FromInts(s []int) *IterInts {
+	return &IterInts{idx: -1, data: s}
+}
+
+// New constructs a new empty IterInts from itself.
+func (*IterInts) New() (Iterable, error) {
+	return NewIterInts(), nil
+}
+
+// Next returns the next int as an interface{}.
+func (is *IterInts) Next() (interface{}, bool) {
+	is.idx++
+	if is.idx < len(is.data) {
+		return is.data[is.idx], true
+	}
+	return nil, false
+}
+
+// Enumerate returns a pair of {index, int as interface}
+// as well as a bool to indicate whether there is more to go.
+func (is *IterInts) Enumerate() (int, interface{}, bool) {
+	is.idx++
+	if is.idx < len(is.data) {
+		return is.idx, is.data[is.idx], true
+	}
+	return -1, nil, false
+}
+
+// Rewind sets the IterInts back to its initial traversal state.
+func (is *IterInts) Rewind() {
+	is.idx = -1
+}
+
+// Reset sets this IterInts to its initial state, dropping its data.
+func (is *IterInts) Reset() {
+	is.Rewind()
+	is.data = nil
+}
+
+// Add appends an item, which must be a int.
+func (is *IterInts) Add(obj interface{}) {
+	is.data = append(is.data, obj.(int))
+}
+
+// Len returns the number of items.
+func (is *IterInts) Len() int {
+	return len(is.data)
+}
+
+// Seek moves IterInts so that the next call to Next returns
+// the item at index i.
+func (is *IterInts) Seek(i int) {
+	is.idx = i - 1
+}
+
+// To returns the underlying []int back.
+func (is *IterInts) To() interface{} {
+	return is.data
+}
+
+// String implements the Stringer interface for IterInts.
+func (is *IterInts) String() string {
+	return fmt.Sprintf("%+v", is.data)
+}
+
+// IterInt64s implements Iterable API for []int64.
+type IterInt64s struct {
+	idx  int
+	data []int64
+}
+
+// NewIterInt64s creates a new empty IterInt64s struct.
+func NewIterInt64s() *IterInt64s {
+	return &IterInt64s{idx: -1}
+}
+
+// FromInt64s creates a new IterInt64s from a []int64.
+func FromInt64s(s []int64) *IterInt64s {
+	return &IterInt64s{idx: -1, data: s}
+}
+
+// New constructs a new empty IterInt64s from itself.
+func (*IterInt64s) New() (Iterable, error) {
+	return NewIterInt64s(), nil
+}
+
+// Next returns the next int64 as an interface{}.
+func (is *IterInt64s) Next() (interface{}, bool) {
+	is.idx++
+	if is.idx < len(is.data) {
+		return is.data[is.idx], true
+	}
+	return nil, false
+}
+
+// Enumerate returns a pair of {index, int64 as interface}
+// as well as a bool to indicate whether there is more to go.
+func (is *IterInt64s) Enumerate() (int, interface{}, bool) {
+	is.idx++
+	if is.idx < len(is.data) {
+		return is.idx, is.data[is.idx], true
+	}
+	return -1, nil, false
+}
+
+// Rewind sets the IterInt64s back to its initial traversal state.
+func (is *IterInt64s) Rewind() {
+	is.idx = -1
+}
+
+// Reset sets this IterInt64s to its initial state, dropping its data.
+func (is *IterInt64s) Reset() {
+	is.Rewind()
+	is.data = nil
+}
+
+// Add appends an item, which must be a int64.
+func (is *IterInt64s) Add(obj interface{}) {
+	is.data = append(is.data, obj.(int64))
+}
+
+// Len returns the number of items.
+func (is *IterInt64s) Len() int {
+	return len(is.data)
+}
+
+// Seek moves IterInt64s so that the next call to Next returns
+// the item at index i.
+func (is *IterInt64s) Seek(i int) {
+	is.idx = i - 1
+}
+
+// To returns the underlying []int64 back.
+func (is *IterInt64s) To() interface{} {
+	return is.data
+}
+
+// String implements the Stringer interface for IterInt64s.
+func (is *IterInt64s) String() string {
+	return fmt.Sprintf("%+v", is.data)
+}
+
+// IterFloats implements Iterable API for []float64.
+type IterFloats struct {
+	idx  int
+	data []float64
+}
+
+// NewIterFloats creates a new empty IterFloats struct.
+func NewIterFloats() *IterFloats {
+	return &IterFloats{idx: -1}
+}
+
+// FromFloats creates a new IterFloats from a []float64.
+func FromFloats(s []float64) *IterFloats {
+	return &IterFloats{idx: -1, data: s}
+}
+
+// New constructs a new empty IterFloats from itself.
+func (*IterFloats) New() (Iterable, error) {
+	return NewIterFloats(), nil
+}
+
+// Next returns the next float64 as an interface{}.
+func (is *IterFloats) Next() (interface{}, bool) {
+	is.idx++
+	if is.idx < len(is.data) {
+		return is.data[is.idx], true
+	}
+	return nil, false
+}
+
+// Enumerate returns a pair of {index, float64 as interface}
+// as well as a bool to indicate whether there is more to go.
+func (is *IterFloats) Enumerate() (int, interface{}, bool) {
+	is.idx++
+	if is.idx < len(is.data) {
+		return is.idx, is.data[is.idx], true
+	}
+	return -1, nil, false
+}
+
+// Rewind sets the IterFloats back to its initial traversal state.
+func (is *IterFloats) Rewind() {
+	is.idx = -1
+}
+
+// Reset sets this IterFloats to its initial state, dropping its data.
+func (is *IterFloats) Reset() {
+	is.Rewind()
+	is.data = nil
+}
+
+// Add appends an item, which must be a float64.
+func (is *IterFloats) Add(obj interface{}) {
+	is.data = append(is.data, obj.(float64))
+}
+
+// Len returns the number of items.
+func (is *IterFloats) Len() int {
+	return len(is.data)
+}
+
+// Seek moves IterFloats so that the next call to Next returns
+// the item at index i.
+func (is *IterFloats) Seek(i int) {
+	is.idx = i - 1
+}
+
+// To returns the underlying []float64 back.
+func (is *IterFloats) To() interface{} {
+	return is.data
+}
+
+// String implements the Stringer interface for IterFloats.
+func (is *IterFloats) String() string {
+	return fmt.Sprintf("%+v", is.data)
+}
+
+// IterBools implements Iterable API for []bool.
+type IterBools struct {
+	idx  int
+	data []bool
+}
+
+// NewIterBools creates a new empty IterBools struct.
+func NewIterBools() *IterBools {
+	return &IterBools{idx: -1}
+}
+
+// FromBools creates a new IterBools from a []bool.
+func FromBools(s []bool) *IterBools {
+	return &IterBools{idx: -1, data: s}
+}
+
+// New constructs a new empty IterBools from itself.
+func (*IterBools) New() (Iterable, error) {
+	return NewIterBools(), nil
+}
+
+// Next returns the next bool as an interface{}.
+func (is *IterBools) Next() (interface{}, bool) {
+	is.idx++
+	if is.idx < len(is.data) {
+		return is.data[is.idx], true
+	}
+	return nil, false
+}
+
+// Enumerate returns a pair of {index, bool as interface}
+// as well as a bool to indicate whether there is more to go.
+func (is *IterBools) Enumerate() (int, interface{}, bool) {
+	is.idx++
+	if is.idx < len(is.data) {
+		return is.idx, is.data[is.idx], true
+	}
+	return -1, nil, false
+}
+
+// Rewind sets the IterBools back to its initial traversal state.
+func (is *IterBools) Rewind() {
+	is.idx = -1
+}
+
+// Reset sets this IterBools to its initial state, dropping its data.
+func (is *IterBools) Reset() {
+	is.Rewind()
+	is.data = nil
+}
+
+// Add appends an item, which must be a bool.
+func (is *IterBools) Add(obj interface{}) {
+	is.data = append(is.data, obj.(bool))
+}
+
+// Len returns the number of items.
+func (is *IterBools) Len() int {
+	return len(is.data)
+}
+
+// Seek moves IterBools so that the next call to Next returns
+// the item at index i.
+func (is *IterBools) Seek(i int) {
+	is.idx = i - 1
+}
+
+// To returns the underlying []bool back.
+func (is *IterBools) To() interface{} {
+	return is.data
+}
+
+// String implements the Stringer interface for IterBools.
+func (is *IterBools) String() string {
+	return fmt.Sprintf("%+v", is.data)
+}
@@ -0,0 +1,50 @@
+package iter
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBuiltins(t *testing.T) {
+	tests := []struct {
+		desc string
+		src  Iterable
+		want string
+	}{
+		{"ints", FromInts([]int{1, -2, 3}), "[1 3]"},
+		{"int64s", FromInt64s([]int64{1, -2, 3}), "[1 3]"},
+		{"floats", FromFloats([]float64{1.5, -2, 3}), "[1.5 3]"},
+		{"bools", FromBools([]bool{true, false, true}), "[true true]"},
+	}
+	positive := func(v interface{}) bool {
+		switch v := v.(type) {
+		case int:
+			return v > 0
+		case int64:
+			return v > 0
+		case float64:
+			return v > 0
+		}
+		return v.(bool)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			it := New(tc.src)
+			if caps := it.Capabilities(); !caps.Enumerator || !caps.Rewinder || !caps.Resetter || !caps.Lener || !caps.Seeker || !caps.FromIter {
+				t.Errorf("capabilities got: %v", caps)
+			}
+			out := it.Filter(positive)
+			if got := fmt.Sprint(out.Collect()); got != tc.want {
+				t.Errorf("Filter got: %s, want: %s", got, tc.want)
+			}
+			if _, ok := out.impl.item.(FromIter).To().([]interface{}); ok {
+				t.Errorf("Filter got an in-memory Iterable, want a %T", tc.src)
+			}
+			it.Rewind()
+			if n := it.Count(); n != 3 {
+				t.Errorf("Count after Rewind got: %d, want: 3", n)
+			}
+		})
+	}
+}
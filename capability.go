@@ -0,0 +1,70 @@
+package iter
+
+import (
+	"io"
+	"strings"
+)
+
+// Capabilities tells which optional interfaces an Iterable implements,
+// so that generic code can pick an algorithm, e.g. Len instead of a
+// full scan, without type assertions.
+type Capabilities struct {
+	Rewinder   bool
+	Resetter   bool
+	Enumerator bool
+	Lener      bool
+	Seeker     bool
+	FromIter   bool
+	// Closer tells whether the Iterable is an io.Closer.
+	Closer bool
+}
+
+// CapabilitiesOf returns the Capabilities of item.
+func CapabilitiesOf(item Iterable) Capabilities {
+	var c Capabilities
+	_, c.Rewinder = item.(Rewinder)
+	_, c.Resetter = item.(Resetter)
+	_, c.Enumerator = item.(Enumerator)
+	_, c.Lener = item.(Lener)
+	_, c.Seeker = item.(Seeker)
+	_, c.FromIter = item.(FromIter)
+	_, c.Closer = item.(io.Closer)
+	return c
+}
+
+// Capabilities returns the Capabilities of the underlying Iterable.
+//
+// Example:
+//   it := New(FromStrings([]string{"a", "b"}))
+//   if it.Capabilities().Lener {
+//      n = it.Iterable().(Lener).Len()
+//   }
+func (it *Iter) Capabilities() Capabilities {
+	return CapabilitiesOf(it.impl.item)
+}
+
+// String implements the Stringer interface for Capabilities, listing the
+// interfaces implemented, e.g. "Rewinder|Lener".
+func (c Capabilities) String() string {
+	var names []string
+	for _, iface := range []struct {
+		ok   bool
+		name string
+	}{
+		{c.Rewinder, "Rewinder"},
+		{c.Resetter, "Resetter"},
+		{c.Enumerator, "Enumerator"},
+		{c.Lener, "Lener"},
+		{c.Seeker, "Seeker"},
+		{c.FromIter, "FromIter"},
+		{c.Closer, "Closer"},
+	} {
+		if iface.ok {
+			names = append(names, iface.name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, "|")
+}
@@ -0,0 +1,26 @@
+package iter
+
+import (
+	"testing"
+)
+
+func TestCapabilities(t *testing.T) {
+	tests := []struct {
+		desc string
+		it   *Iter
+		want string
+	}{
+		{"strings", New(FromStrings([]string{"a"})), "Rewinder|Resetter|Enumerator|Lener|Seeker|FromIter"},
+		{"pipeline", NewPipeline().Run(FromStrings([]string{"a"})), "Rewinder|FromIter"},
+		{"closer", New(closingStrings{FromStrings(nil), new(int)}), "Rewinder|Resetter|Enumerator|Lener|Seeker|FromIter|Closer"},
+		{"reorder", New(FromStrings(nil)).Reorder(0, stamps), "none"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.it.Capabilities().String(); got != tc.want {
+				t.Errorf("Capabilities got: %s, want: %s", got, tc.want)
+			}
+		})
+	}
+}
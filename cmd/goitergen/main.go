@@ -0,0 +1,255 @@
+// Command goitergen generates a typed Iterable implementation for the
+// github.com/i3d/goiter package, sparing the boilerplate of writing an
+// IterStrings-like type by hand for every []T.
+//
+// Typical usage is through go generate:
+//
+//   //go:generate goitergen -type Point
+//
+// which writes iter_point.go next to the file, containing an IterPoints
+// type with New/Add/Next/Enumerate/Rewind/Reset/Len/Seek/To, as well as
+// NewIterPoints and FromPoints constructors.
+//
+// Flags:
+//   -type     the element type, e.g. int, Point, *Point or *geo.Point
+//             (required)
+//   -import   the import path of the package of a qualified type, e.g.
+//             example.com/geo for *geo.Point
+//   -plural   the plural name of the type used in the generated names,
+//             defaults to the capitalized type name followed by "s"
+//   -package  the package of the generated file, defaults to $GOPACKAGE,
+//             iter stands for github.com/i3d/goiter itself
+//   -output   the output file, defaults to iter_<type>.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("goitergen: ")
+
+	typ := flag.String("type", "", "the element type, e.g. int, Point, *Point or *geo.Point")
+	imp := flag.String("import", "", "the import path of the package of a qualified type")
+	plural := flag.String("plural", "", "the plural name of the type, defaults to the capitalized type name followed by \"s\"")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "the package of the generated file")
+	output := flag.String("output", "", "the output file, defaults to iter_<type>.go")
+	flag.Parse()
+
+	if *typ == "" || *pkg == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg := config{Type: *typ, Import: *imp, Plural: *plural, Package: *pkg}
+	src, err := generate(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out := *output
+	if out == "" {
+		out = "iter_" + strings.ToLower(baseName(*typ)) + ".go"
+	}
+	if err := os.WriteFile(out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// goiterName is the name of the github.com/i3d/goiter package.
+const goiterName = "iter"
+
+// config describes the Iterable to be generated.
+type config struct {
+	Type    string
+	Import  string
+	Plural  string
+	Package string
+
+	// Set by generate: the name Import is imported as, if not the default
+	// one, and the qualifier of the goiter identifiers.
+	ImportName string
+	Iter       string
+}
+
+// generate returns the formatted source of the Iterable described by cfg.
+func generate(cfg config) ([]byte, error) {
+	if cfg.Plural == "" {
+		name := baseName(cfg.Type)
+		if name == "" {
+			return nil, fmt.Errorf("can't derive a name from type %q, please set -plural", cfg.Type)
+		}
+		cfg.Plural = capitalize(name) + "s"
+	}
+
+	qual := qualifier(cfg.Type)
+	switch {
+	case qual != "" && cfg.Import == "":
+		return nil, fmt.Errorf("type %q is qualified by %s, please set -import", cfg.Type, qual)
+	case cfg.Import != "" && qual != "" && qual != path.Base(cfg.Import):
+		cfg.ImportName = qual
+	}
+	// The generated code lives in goiter itself, which can't import itself.
+	if cfg.Package != goiterName {
+		cfg.Iter = goiterName + "."
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, cfg); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid code for type %q: %v", cfg.Type, err)
+	}
+	return src, nil
+}
+
+// qualifier returns the package qualifier of a type, e.g. *geo.Point =>
+// geo, or an empty string if there is none. The qualifiers within map
+// or func types are not looked for, see -import.
+func qualifier(typ string) string {
+	name := strings.TrimLeft(typ, "*[]")
+	i := strings.LastIndex(name, ".")
+	if i < 0 || baseName(name[:i]) != name[:i] {
+		return ""
+	}
+	return name[:i]
+}
+
+// baseName strips pointers, slices and package qualifiers off a type,
+// e.g. *geo.Point => Point. An empty string is returned if there is no
+// identifier left, e.g. for map or func types.
+func baseName(typ string) string {
+	name := strings.TrimLeft(typ, "*[]")
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return ""
+		}
+	}
+	return name
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+var tmpl = template.Must(template.New("iterable").Parse(`// Code generated by goitergen -type {{.Type}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"fmt"
+{{if .Import}}
+	{{.ImportName}} "{{.Import}}"
+{{- end}}
+{{- if .Iter}}
+
+	iter "github.com/i3d/goiter"
+{{- end}}
+)
+
+// Iter{{.Plural}} implements Iterable API for []{{.Type}}.
+// Iter{{.Plural}} itself is not thread-safe.
+type Iter{{.Plural}} struct {
+	idx  int
+	data []{{.Type}}
+	size int
+}
+
+// NewIter{{.Plural}} creates a new empty Iter{{.Plural}} struct.
+func NewIter{{.Plural}}() *Iter{{.Plural}} {
+	return &Iter{{.Plural}}{idx: -1}
+}
+
+// From{{.Plural}} creates a new Iter{{.Plural}} from a []{{.Type}}.
+func From{{.Plural}}(s []{{.Type}}) *Iter{{.Plural}} {
+	return &Iter{{.Plural}}{idx: -1, data: s, size: len(s)}
+}
+
+// New constructs a new empty Iter{{.Plural}} from itself.
+func (is *Iter{{.Plural}}) New() ({{.Iter}}Iterable, error) {
+	return NewIter{{.Plural}}(), nil
+}
+
+// Next returns the next {{.Type}} as an interface{}.
+// bool indicate whether there is any more to go. If false,
+// then Iter{{.Plural}} is exhausted.
+func (is *Iter{{.Plural}}) Next() (interface{}, bool) {
+	is.idx++
+	if is.idx < is.size {
+		return is.data[is.idx], true
+	}
+	return nil, false
+}
+
+// Rewind for Iter{{.Plural}} will set the Iterable to its initial
+// traversal state and ready for start from beginning again.
+func (is *Iter{{.Plural}}) Rewind() {
+	is.idx = -1
+}
+
+// Reset sets this Iter{{.Plural}} to it's initial state.
+// Whatever data hosted would be lost after this call.
+func (is *Iter{{.Plural}}) Reset() {
+	is.Rewind()
+	is.data = nil
+	is.size = 0
+}
+
+// Add inserts a {{.Type}} as an interface into the Iter{{.Plural}} struct.
+func (is *Iter{{.Plural}}) Add(obj interface{}) {
+	input := obj.({{.Type}})
+	is.data = append(is.data, input)
+	is.size++
+}
+
+// Len returns the number of items.
+func (is *Iter{{.Plural}}) Len() int {
+	return is.size
+}
+
+// Seek moves Iter{{.Plural}} so that the next call to Next returns
+// the item at index i.
+func (is *Iter{{.Plural}}) Seek(i int) {
+	is.idx = i - 1
+}
+
+// Enumerate returns a pair of {index, {{.Type}} as interface}
+// as well as a bool to indicate whether there is more to go.
+func (is *Iter{{.Plural}}) Enumerate() (int, interface{}, bool) {
+	is.idx++
+	if is.idx < is.size {
+		return is.idx, is.data[is.idx], true
+	}
+	return -1, nil, false
+}
+
+// To returns the underlying []{{.Type}} back.
+func (is *Iter{{.Plural}}) To() interface{} {
+	return is.data
+}
+
+// String implements the Stringer interface for Iter{{.Plural}}.
+func (is *Iter{{.Plural}}) String() string {
+	return fmt.Sprintf("%+v", is.data)
+}
+`))
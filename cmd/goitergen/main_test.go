@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		cfg      config
+		wantType string
+		wantErr  bool
+	}{
+		{config{Type: "int", Package: "p"}, "type IterInts struct", false},
+		{config{Type: "*geo.Point", Import: "example.com/gen/geo", Package: "p"}, "type IterPoints struct", false},
+		{config{Type: "*geo.Point", Package: "p"}, "", true},
+		{config{Type: "Person", Plural: "People", Package: "p"}, "type IterPeople struct", false},
+		{config{Type: "map[string]int", Package: "p"}, "", true},
+		{config{Type: "map[string]int", Plural: "Counts", Package: "p"}, "type IterCounts struct", false},
+	}
+
+	for _, tc := range tests {
+		src, err := generate(tc.cfg)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("generate(%+v) got error: %v, want error: %t", tc.cfg, err, tc.wantErr)
+			continue
+		}
+		if err == nil && !strings.Contains(string(src), tc.wantType) {
+			t.Errorf("generate(%+v) doesn't contain %q:\n%s", tc.cfg, tc.wantType, src)
+		}
+	}
+}
+
+func TestGenerateCompiles(t *testing.T) {
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	root, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A module using the generated code, qualified types included.
+	gen := t.TempDir()
+	files := map[string]string{
+		"go.mod":          "module example.com/gen\n\ngo 1.18\n\nrequire github.com/i3d/goiter v0.0.0\n\nreplace github.com/i3d/goiter => " + root + "\n",
+		"geo/geo.go":      "package geo\n\ntype Point struct{ X, Y int }\n",
+		"p/iter_int.go":   mustGenerate(t, config{Type: "int", Package: "p"}),
+		"p/iter_point.go": mustGenerate(t, config{Type: "*geo.Point", Import: "example.com/gen/geo", Package: "p"}),
+		"q/iter_point.go": mustGenerate(t, config{Type: "geo.Point", Import: "example.com/gen/geo", Plural: "Places", Package: "q"}),
+	}
+	writeFiles(t, gen, files)
+	goBuild(t, gobin, gen, "./...")
+
+	// The file generated into goiter itself is added by an overlay.
+	self := t.TempDir()
+	files = map[string]string{
+		"iter_complex128.go": mustGenerate(t, config{Type: "complex128", Package: "iter"}),
+		"overlay.json": fmt.Sprintf(`{"Replace": {%q: %q}}`,
+			filepath.Join(root, "iter_complex128.go"), filepath.Join(self, "iter_complex128.go")),
+	}
+	writeFiles(t, self, files)
+	goBuild(t, gobin, root, "-overlay", filepath.Join(self, "overlay.json"), ".")
+}
+
+func mustGenerate(t *testing.T, cfg config) string {
+	t.Helper()
+	src, err := generate(cfg)
+	if err != nil {
+		t.Fatalf("generate(%+v) got error: %v", cfg, err)
+	}
+	return string(src)
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func goBuild(t *testing.T, gobin, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command(gobin, append([]string{"build"}, args...)...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("go build in %s failed: %v\n%s", dir, err, out)
+	}
+}
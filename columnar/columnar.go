@@ -0,0 +1,170 @@
+// Package columnar reads and writes columnar data, such as Apache Arrow
+// IPC streams or Parquet files, as streams of rows of the
+// github.com/i3d/goiter package.
+//
+// To keep goiter free of external dependencies, this package doesn't
+// link any Arrow or Parquet library. Instead, it works through the small
+// BatchReader and BatchWriter interfaces, which are meant to be
+// implemented by a few lines of shim around the library of your choice,
+// e.g. an ipc.Reader of github.com/apache/arrow/go or a row group reader
+// of a Parquet library. A Batch is then an Arrow record or a Parquet
+// row group.
+package columnar
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	iter "github.com/i3d/goiter"
+)
+
+// Batch is a chunk of rows stored column by column.
+type Batch interface {
+	// Columns returns the names of the columns.
+	Columns() []string
+	// NumRows returns the number of rows.
+	NumRows() int
+	// Value returns the value of the column col at the row row.
+	Value(col, row int) interface{}
+}
+
+// BatchReader reads the Batches of a columnar source in order.
+type BatchReader interface {
+	// Read returns the next Batch, or io.EOF when there is no more.
+	Read() (Batch, error)
+}
+
+// BatchWriter writes Batches to a columnar destination.
+type BatchWriter interface {
+	// Write writes the rows, each of them has one value per column,
+	// as a single Batch, e.g. one Arrow record or Parquet row group.
+	Write(columns []string, rows [][]interface{}) error
+	// Close flushes and finishes the destination.
+	Close() error
+}
+
+// Row is a row read from a Batch, keyed by the column names.
+type Row map[string]interface{}
+
+// Source is an Iterable of the Rows of a BatchReader. The Batches are
+// read lazily, one at a time, so only one of them is held in memory.
+//
+// Next reports no more items on the end of the BatchReader as well as
+// on an error, which is then returned by Err.
+type Source struct {
+	r     BatchReader
+	batch Batch
+	row   int
+	err   error
+	rows  []Row
+}
+
+// NewSource creates a Source reading r.
+func NewSource(r BatchReader) *Source {
+	return &Source{r: r}
+}
+
+// New implements iter.Iterable. It returns an in-memory Source,
+// so that the APIs producing new Iterators can store their Rows.
+func (*Source) New() (iter.Iterable, error) {
+	return &Source{}, nil
+}
+
+// Add implements iter.Iterable. Only the Sources created by New
+// accept items, which must be Rows.
+func (s *Source) Add(obj interface{}) {
+	if s.r != nil {
+		panic("columnar: can't Add to a Source reading a BatchReader")
+	}
+	s.rows = append(s.rows, obj.(Row))
+}
+
+// Next implements iter.Iterable.
+func (s *Source) Next() (interface{}, bool) {
+	if s.r == nil {
+		if s.row < len(s.rows) {
+			s.row++
+			return s.rows[s.row-1], true
+		}
+		return nil, false
+	}
+
+	for s.batch == nil || s.row >= s.batch.NumRows() {
+		if s.err != nil {
+			return nil, false
+		}
+		b, err := s.r.Read()
+		if err != nil {
+			s.err = err
+			return nil, false
+		}
+		s.batch, s.row = b, 0
+	}
+
+	cols := s.batch.Columns()
+	row := make(Row, len(cols))
+	for c, name := range cols {
+		row[name] = s.batch.Value(c, s.row)
+	}
+	s.row++
+	return row, true
+}
+
+// Err returns the error the BatchReader failed with, if any.
+func (s *Source) Err() error {
+	if errors.Is(s.err, io.EOF) {
+		return nil
+	}
+	return s.err
+}
+
+// Write drains it into w, batchSize Rows per Batch, and closes w.
+//
+// columns names the columns to write, values extracts the values of an
+// item in the same order, e.g. the fields of a struct. If values is nil,
+// the items must be Rows.
+//
+// Write panics if batchSize is less than 1.
+//
+// Example:
+//   err := columnar.Write(it, writer, 10000, []string{"name", "age"},
+//      func(v interface{}) []interface{} {
+//         p := v.(*Person)
+//         return []interface{}{p.Name, p.Age}
+//      })
+func Write(it *iter.Iter, w BatchWriter, batchSize int, columns []string, values func(interface{}) []interface{}) error {
+	if batchSize < 1 {
+		panic(fmt.Sprintf("columnar: Write needs a batch size of at least 1, got %d", batchSize))
+	}
+	if values == nil {
+		values = func(v interface{}) []interface{} {
+			row := v.(Row)
+			out := make([]interface{}, len(columns))
+			for i, c := range columns {
+				out[i] = row[c]
+			}
+			return out
+		}
+	}
+
+	var err error
+	rows := make([][]interface{}, 0, batchSize)
+	it.Each(func(v interface{}) {
+		if err != nil {
+			return
+		}
+		rows = append(rows, values(v))
+		if len(rows) == batchSize {
+			err = w.Write(columns, rows)
+			rows = make([][]interface{}, 0, batchSize)
+		}
+	})
+	if err == nil && len(rows) > 0 {
+		err = w.Write(columns, rows)
+	}
+	if cerr := w.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,85 @@
+package columnar
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	iter "github.com/i3d/goiter"
+)
+
+// batch is an in-memory Batch, standing for an Arrow record.
+type batch struct {
+	cols []string
+	rows [][]interface{}
+}
+
+func (b *batch) Columns() []string              { return b.cols }
+func (b *batch) NumRows() int                   { return len(b.rows) }
+func (b *batch) Value(col, row int) interface{} { return b.rows[row][col] }
+
+// file is an in-memory BatchReader and BatchWriter.
+type file struct {
+	batches []*batch
+	err     error
+	closed  bool
+}
+
+func (f *file) Read() (Batch, error) {
+	if len(f.batches) == 0 {
+		if f.err != nil {
+			return nil, f.err
+		}
+		return nil, io.EOF
+	}
+	b := f.batches[0]
+	f.batches = f.batches[1:]
+	return b, nil
+}
+
+func (f *file) Write(cols []string, rows [][]interface{}) error {
+	f.batches = append(f.batches, &batch{cols, rows})
+	return nil
+}
+
+func (f *file) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestRoundTrip(t *testing.T) {
+	cols := []string{"name", "age"}
+	out := &file{}
+	it := iter.New(iter.FromStrings([]string{"ann", "bob", "cid"}))
+	err := Write(it, out, 2, cols, func(v interface{}) []interface{} {
+		return []interface{}{v, len(v.(string))}
+	})
+	if err != nil || !out.closed || len(out.batches) != 2 {
+		t.Fatalf("Write got %d batches, closed %v, error: %v", len(out.batches), out.closed, err)
+	}
+
+	written := append([]*batch(nil), out.batches...)
+	src := NewSource(out)
+	adults := iter.New(src).Filter(func(v interface{}) bool {
+		return v.(Row)["name"] != "bob"
+	})
+	var names []interface{}
+	adults.Each(func(v interface{}) { names = append(names, v.(Row)["name"]) })
+	if fmt.Sprint(names) != "[ann cid]" || src.Err() != nil {
+		t.Errorf("Source read: %v with error: %v, want: [ann cid]", names, src.Err())
+	}
+
+	again := &file{}
+	if err := Write(iter.New(NewSource(&file{batches: written})), again, 10, cols, nil); err != nil || fmt.Sprint(again.batches[0].rows) != "[[ann 3] [bob 3] [cid 3]]" {
+		t.Errorf("Write of Rows got: %v with error: %v", again.batches[0].rows, err)
+	}
+}
+
+func TestSourceError(t *testing.T) {
+	broken := errors.New("broken")
+	src := NewSource(&file{batches: []*batch{{[]string{"x"}, [][]interface{}{{1}}}}, err: broken})
+	if n := iter.New(src).Count(); n != 1 || src.Err() != broken {
+		t.Errorf("Source read %d rows with error: %v, want 1 row and %v", n, src.Err(), broken)
+	}
+}
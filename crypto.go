@@ -0,0 +1,122 @@
+package iter
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrBadMAC is returned by VerifyHMAC for an item whose tag doesn't
+// match its content.
+var ErrBadMAC = errors.New("iter: HMAC mismatch")
+
+// EncryptAES returns a ConvertFunc encrypting []byte (or string) items
+// with AES-GCM under key, 16, 24 or 32 bytes long for AES-128, AES-192 or
+// AES-256, e.g. before staging sensitive records on disk or in a queue.
+// Every item gets a random nonce, prepended to the []byte it becomes.
+//
+// Example:
+//   encrypt, err := EncryptAES(key)
+//   if err != nil {
+//      return err
+//   }
+//   sealed, err := it.TryMap(encrypt)
+func EncryptAES(key []byte) (ConvertFunc, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	return func(v interface{}) (interface{}, error) {
+		plain, err := bytesOf(v)
+		if err != nil {
+			return nil, err
+		}
+		nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plain)+aead.Overhead())
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			return nil, err
+		}
+		return aead.Seal(nonce, nonce, plain, nil), nil
+	}, nil
+}
+
+// DecryptAES returns a ConvertFunc decrypting the []byte items produced
+// by EncryptAES under the same key. An item which was altered, or
+// encrypted under another key, fails.
+func DecryptAES(key []byte) (ConvertFunc, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	return func(v interface{}) (interface{}, error) {
+		sealed, err := bytesOf(v)
+		if err != nil {
+			return nil, err
+		}
+		if len(sealed) < aead.NonceSize() {
+			return nil, fmt.Errorf("iter: AES-GCM item too short: %d bytes", len(sealed))
+		}
+		n := aead.NonceSize()
+		return aead.Open(nil, sealed[:n], sealed[n:], nil)
+	}, nil
+}
+
+// SignHMAC returns a MapFunc appending to every []byte (or string) item
+// its HMAC-SHA256 tag under key, so that the items can be checked by
+// VerifyHMAC once read back. The MapFunc panics for any other item.
+func SignHMAC(key []byte) MapFunc {
+	return func(v interface{}) interface{} {
+		b, err := bytesOf(v)
+		if err != nil {
+			panic(err.Error())
+		}
+		mac := hmac.New(sha256.New, key)
+		mac.Write(b)
+		return mac.Sum(append([]byte(nil), b...))
+	}
+}
+
+// VerifyHMAC returns a ConvertFunc checking the tag of the items produced
+// by SignHMAC under the same key and stripping it, the error wraps
+// ErrBadMAC for an item whose tag doesn't match.
+func VerifyHMAC(key []byte) ConvertFunc {
+	return func(v interface{}) (interface{}, error) {
+		b, err := bytesOf(v)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) < sha256.Size {
+			return nil, fmt.Errorf("%w: item too short: %d bytes", ErrBadMAC, len(b))
+		}
+		data, tag := b[:len(b)-sha256.Size], b[len(b)-sha256.Size:]
+		mac := hmac.New(sha256.New, key)
+		mac.Write(data)
+		if !hmac.Equal(tag, mac.Sum(nil)) {
+			return nil, ErrBadMAC
+		}
+		return data, nil
+	}
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// bytesOf returns the content of a []byte or string item.
+func bytesOf(v interface{}) ([]byte, error) {
+	switch b := v.(type) {
+	case []byte:
+		return b, nil
+	case string:
+		return []byte(b), nil
+	}
+	return nil, fmt.Errorf("iter: %#v (%T) is neither a []byte nor a string", v, v)
+}
@@ -0,0 +1,53 @@
+package iter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestAES(t *testing.T) {
+	if _, err := EncryptAES([]byte("short")); err == nil {
+		t.Error("EncryptAES with a 5 bytes key got no error")
+	}
+	key := bytes.Repeat([]byte{7}, 32)
+	encrypt, err := EncryptAES(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypt, _ := DecryptAES(key)
+
+	sealed, err := New(FromStrings([]string{"secret", "data"})).TryMap(encrypt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := sealed.Collect().([]interface{})
+	if bytes.Contains(items[0].([]byte), []byte("secret")) {
+		t.Errorf("EncryptAES leaked the plaintext: %q", items[0])
+	}
+
+	opened, err := New(sealed.Iterable()).TryMap(decrypt)
+	if got := fmt.Sprintf("%s", opened.Collect()); err != nil || got != "[secret data]" {
+		t.Errorf("DecryptAES got: %s, %v, want: [secret data]", got, err)
+	}
+
+	items[1].([]byte)[20] ^= 1
+	if _, err := decrypt(items[1]); err == nil {
+		t.Error("DecryptAES of an altered item got no error")
+	}
+}
+
+func TestHMAC(t *testing.T) {
+	key := []byte("k")
+	src, _ := FromSlice([]interface{}{[]byte("a"), "b"})
+	signed := New(src).Map(SignHMAC(key)).Collect().([]interface{})
+
+	verify := VerifyHMAC(key)
+	if v, err := verify(signed[0]); err != nil || string(v.([]byte)) != "a" {
+		t.Errorf("VerifyHMAC got: %q, %v, want: a", v, err)
+	}
+	if _, err := VerifyHMAC([]byte("other"))(signed[1]); !errors.Is(err, ErrBadMAC) {
+		t.Errorf("VerifyHMAC under another key got error: %v, want: %v", err, ErrBadMAC)
+	}
+}
@@ -0,0 +1,227 @@
+package iter
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DebugEventKind is the kind of a DebugEvent.
+type DebugEventKind int
+
+const (
+	// ItemPulled is an item read from the source.
+	ItemPulled DebugEventKind = iota
+	// StageEntered is an item entering a stage.
+	StageEntered
+	// ItemEmitted is an item emitted by a stage.
+	ItemEmitted
+	// StageExhausted is the end of the items of the source or of a stage,
+	// reported for the source first then for every stage in order.
+	StageExhausted
+)
+
+// String implements the Stringer interface for DebugEventKind.
+func (k DebugEventKind) String() string {
+	switch k {
+	case ItemPulled:
+		return "pulled"
+	case StageEntered:
+		return "entered"
+	case ItemEmitted:
+		return "emitted"
+	case StageExhausted:
+		return "exhausted"
+	}
+	return fmt.Sprintf("DebugEventKind(%d)", int(k))
+}
+
+// DebugEvent is a step of the execution of a Pipeline, see RunDebug.
+type DebugEvent struct {
+	// Seq numbers the events of a run from 1, in the order they happen.
+	Seq  uint64
+	Kind DebugEventKind
+	// Stage is the index of the stage, the one of the outermost stage
+	// for a nested one, -1 for the source.
+	Stage int
+	// Name is the name of the stage, e.g. "Filter" or "Bind.Filter", or
+	// "Source".
+	Name string
+	// Item is the item involved, nil for StageExhausted.
+	Item interface{}
+}
+
+// String implements the Stringer interface for DebugEvent, e.g.
+// "#3 1:Filter emitted a".
+func (e DebugEvent) String() string {
+	if e.Kind == StageExhausted {
+		return fmt.Sprintf("#%d %d:%s exhausted", e.Seq, e.Stage, e.Name)
+	}
+	return fmt.Sprintf("#%d %d:%s %v %v", e.Seq, e.Stage, e.Name, e.Kind, e.Item)
+}
+
+// DebugObserver receives the DebugEvents of a run, see RunDebug.
+type DebugObserver interface {
+	OnEvent(DebugEvent)
+}
+
+// DebugFunc is a function implementing DebugObserver.
+type DebugFunc func(DebugEvent)
+
+// OnEvent implements DebugObserver for DebugFunc.
+func (f DebugFunc) OnEvent(e DebugEvent) {
+	f(e)
+}
+
+// RunDebug is like Run but reports every step of the execution to o, as
+// it happens, e.g. for an external tool to diagnose an ordering or a
+// consumption bug. OnEvent is called on the goroutine reading the
+// returned Iterator, so an interactive debugger steps through the run by
+// blocking in OnEvent until the user resumes it.
+//
+// The stages nested in another one, e.g. by Bind or WhenFunc, are
+// reported with the index of the outer stage and their names joined by
+// dots, e.g. "Bind.Filter". Same as Run, the returned Iterable is a
+// Rewinder only if src is one. The sequence numbers start over when the
+// Iterator is rewound.
+//
+// Example:
+//   it := p.RunDebug(src, DebugFunc(func(e DebugEvent) { log.Print(e) }))
+//   it.Each(handle)
+//   logs e.g. "#1 -1:Source pulled a", "#2 0:Filter entered a",
+//   "#3 0:Filter emitted a", ...
+func (p *Pipeline) RunDebug(src Iterable, o DebugObserver) *Iter {
+	return p.RunDebugWith(src, nil, o)
+}
+
+// RunDebugWith is like RunDebug, the stages added by Bind are built from
+// ps, see RunWith.
+func (p *Pipeline) RunDebugWith(src Iterable, ps Params, o DebugObserver) *Iter {
+	d := &debugSource{src: src, o: o}
+	stages := d.wrap(p.stages, nil, "")
+	if _, ok := src.(Rewinder); ok {
+		return New(newLazy(rewindableDebugSource{d}, stages, ps))
+	}
+	return New(newLazy(d, stages, ps))
+}
+
+// debugSource reports the items read from src and its end.
+type debugSource struct {
+	src       Iterable
+	o         DebugObserver
+	seq       uint64
+	exhausted bool
+	// built are the stages built for the run, nested ones included.
+	built []debugStage
+}
+
+// debugStage is a stage of a run reporting to a debugSource.
+type debugStage struct {
+	// path holds the index of the stage within every Pipeline it is
+	// nested in, from the outermost one.
+	path []int
+	name string
+}
+
+// wrap returns stages reporting their steps. path is the one of the
+// stage they are nested in, if any, prefix its name followed by a dot.
+func (d *debugSource) wrap(stages []stage, path []int, prefix string) []stage {
+	out := make([]stage, len(stages))
+	for i, st := range stages {
+		ds := debugStage{path: append(append([]int(nil), path...), i), name: prefix + st.name}
+		st := st
+		out[i] = st
+		out[i].nest = nil
+		out[i].build = func(ps Params) step {
+			var s step
+			if st.nest != nil {
+				s = st.nest(ps, func(inner []stage, sink func(interface{}), ps Params) func(interface{}) {
+					return compose(d.wrap(inner, ds.path, ds.name+"."), sink, ps)
+				})
+			} else {
+				s = st.build(ps)
+			}
+			d.built = append(d.built, ds)
+			return func(v interface{}, emit func(interface{})) {
+				d.report(StageEntered, ds, v)
+				s(v, func(out interface{}) {
+					d.report(ItemEmitted, ds, out)
+					emit(out)
+				})
+			}
+		}
+	}
+	return out
+}
+
+// sourceStage is the debugStage of the source.
+var sourceStage = debugStage{name: "Source"}
+
+func (d *debugSource) report(k DebugEventKind, ds debugStage, v interface{}) {
+	d.seq++
+	i := -1
+	if len(ds.path) > 0 {
+		i = ds.path[0]
+	}
+	d.o.OnEvent(DebugEvent{Seq: d.seq, Kind: k, Stage: i, Name: ds.name, Item: v})
+}
+
+// Err returns the error of src, if any.
+func (d *debugSource) Err() error {
+	return errOf(d.src)
+}
+
+func (d *debugSource) New() (Iterable, error) {
+	return d.src.New()
+}
+
+func (*debugSource) Add(interface{}) {
+	panic("iter: Add is not supported by a Pipeline Iterable")
+}
+
+func (d *debugSource) Next() (interface{}, bool) {
+	if d.exhausted {
+		return nil, false
+	}
+	v, more := d.src.Next()
+	if !more {
+		d.exhausted = true
+		d.report(StageExhausted, sourceStage, nil)
+		// In the order of the stages, compose builds them backwards.
+		sort.Slice(d.built, func(i, j int) bool {
+			return lessPath(d.built[i].path, d.built[j].path)
+		})
+		for _, ds := range d.built {
+			d.report(StageExhausted, ds, nil)
+		}
+		return nil, false
+	}
+	d.report(ItemPulled, sourceStage, v)
+	return v, true
+}
+
+// rewindableDebugSource is a debugSource over a Rewinder, which makes
+// the run a Rewinder, see Run.
+type rewindableDebugSource struct {
+	*debugSource
+}
+
+func (d rewindableDebugSource) Rewind() {
+	d.src.(Rewinder).Rewind()
+	d.seq, d.exhausted, d.built = 0, false, nil
+}
+
+// lessPath tells whether the stage at path a comes before the one at b,
+// a stage coming before the ones nested in it.
+func lessPath(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// SizeHint forwards the size of src.
+func (d *debugSource) SizeHint() (int, int) {
+	return sizeHintOf(d.src)
+}
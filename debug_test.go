@@ -0,0 +1,78 @@
+package iter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRunDebug(t *testing.T) {
+	var events []string
+	p := NewPipeline().
+		Filter(func(v interface{}) bool { return v.(string) != "b" }).
+		Map(func(v interface{}) interface{} { return strings.ToUpper(v.(string)) })
+	it := p.RunDebug(FromStrings([]string{"a", "b"}), DebugFunc(func(e DebugEvent) {
+		events = append(events, e.String())
+	}))
+
+	if got := fmt.Sprint(it.Collect()); got != "[A]" {
+		t.Errorf("RunDebug got: %s, want: [A]", got)
+	}
+	want := []string{
+		"#1 -1:Source pulled a",
+		"#2 0:Filter entered a",
+		"#3 0:Filter emitted a",
+		"#4 1:Map entered a",
+		"#5 1:Map emitted A",
+		"#6 -1:Source pulled b",
+		"#7 0:Filter entered b",
+		"#8 -1:Source exhausted",
+		"#9 0:Filter exhausted",
+		"#10 1:Map exhausted",
+	}
+	if strings.Join(events, "\n") != strings.Join(want, "\n") {
+		t.Errorf("RunDebug events got:\n%s\nwant:\n%s", strings.Join(events, "\n"), strings.Join(want, "\n"))
+	}
+
+	events = events[:0]
+	it.Rewind()
+	it.Count()
+	if len(events) != len(want) || events[0] != want[0] {
+		t.Errorf("RunDebug after Rewind got events: %v, want them to start over", events)
+	}
+
+	// The nested stages are built from the Params of the run and report
+	// their steps as well.
+	events = events[:0]
+	p = NewPipeline().Bind(func(ps Params) *Pipeline {
+		prefix := ps.String("prefix", "")
+		return NewPipeline().Map(func(v interface{}) interface{} { return prefix + v.(string) })
+	})
+	it = p.RunDebugWith(FromStrings([]string{"a"}), Params{"prefix": "x"}, DebugFunc(func(e DebugEvent) {
+		events = append(events, e.String())
+	}))
+	if got := fmt.Sprint(it.Collect()); got != "[xa]" {
+		t.Errorf("RunDebugWith got: %s, want: [xa]", got)
+	}
+	want = []string{
+		"#1 -1:Source pulled a",
+		"#2 0:Bind entered a",
+		"#3 0:Bind.Map entered a",
+		"#4 0:Bind.Map emitted xa",
+		"#5 0:Bind emitted xa",
+		"#6 -1:Source exhausted",
+		"#7 0:Bind exhausted",
+		"#8 0:Bind.Map exhausted",
+	}
+	if strings.Join(events, "\n") != strings.Join(want, "\n") {
+		t.Errorf("RunDebugWith events got:\n%s\nwant:\n%s", strings.Join(events, "\n"), strings.Join(want, "\n"))
+	}
+
+	once := NewPipeline().RunDebug(oneShot{FromStrings([]string{"a", "b"})}, DebugFunc(func(DebugEvent) {}))
+	if once.Capabilities().Rewinder {
+		t.Error("RunDebug over a one-shot source is a Rewinder")
+	}
+	if n := once.Count(); n != 2 || !once.Consumed() {
+		t.Errorf("RunDebug over a one-shot source got Count: %d, Consumed: %t, want: 2, true", n, once.Consumed())
+	}
+}
@@ -0,0 +1,140 @@
+package iter
+
+import "reflect"
+
+// Dedup removes the consecutive repeated items of the Iterable, keeping
+// the first one of every run, and returns a new Iterator contains the
+// rest, e.g. to collapse repeated log lines or the duplicates of a
+// sorted Iterable. Items are compared with ==, which panics for items of
+// uncomparable types such as slices, see DedupBy.
+//
+// Example:
+//   it := New(FromStrings([]string{"a", "a", "b", "a"}))
+//   newit := it.Dedup()
+//   produces a newit contains []string{"a", "b", "a"}
+func (it *Iter) Dedup() *Iter {
+	return newFromImpl(it.impl.dedup("Dedup", func(x, y interface{}) bool { return x == y }))
+}
+
+// DedupBy is like Dedup but considers two consecutive items the same
+// when eq returns true given the first item of the run and the item.
+//
+// Example:
+//   it := New(FromStrings([]string{"a", "A", "b"}))
+//   newit := it.DedupBy(func(x, y interface{}) bool {
+//      return strings.EqualFold(x.(string), y.(string))
+//   })
+//   produces a newit contains []string{"a", "b"}
+func (it *Iter) DedupBy(eq EqualFunc) *Iter {
+	return newFromImpl(it.impl.dedup("DedupBy", eq))
+}
+
+func (it *iter) dedup(stage string, eq EqualFunc) *iter {
+	newitem := it.newItem()
+
+	var last interface{}
+	var read, emitted int
+	for {
+		elm, more := it.next()
+		if !more {
+			break
+		}
+		read++
+		if emitted > 0 && eq(last, elm) {
+			continue
+		}
+		newitem.Add(elm)
+		last = elm
+		emitted++
+	}
+	return it.derive(stage, newitem, read, emitted)
+}
+
+// Unique keeps the first item of every key and returns a new Iterator
+// contains them, in their original order. Unlike Dedup, it removes the
+// duplicates wherever they are, at the cost of holding every key seen in
+// memory. If key is nil, the item itself is the key. Keys must be
+// comparable, see map keys.
+//
+// Example:
+//   it := New(FromStrings([]string{"apple", "banana", "avocado"}))
+//   newit := it.Unique(func(v interface{}) interface{} {
+//      return v.(string)[:1]
+//   })
+//   produces a newit contains []string{"apple", "banana"}
+func (it *Iter) Unique(key func(interface{}) interface{}) *Iter {
+	return newFromImpl(it.impl.unique(key))
+}
+
+func (it *iter) unique(key func(interface{}) interface{}) *iter {
+	newitem := it.newItem()
+	seen := map[interface{}]struct{}{}
+
+	var read, emitted int
+	for {
+		elm, more := it.next()
+		if !more {
+			break
+		}
+		read++
+		k := elm
+		if key != nil {
+			k = key(elm)
+		}
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		newitem.Add(elm)
+		emitted++
+	}
+	return it.derive("Unique", newitem, read, emitted)
+}
+
+// Intern returns a new Iterator contains the items of the Iterable, every
+// duplicate being replaced by the first item equal to it, so that they
+// share one instance, e.g. the backing array of a string, instead of one
+// copy each. This cuts the memory of a stream dominated by a few repeated
+// values, such as the status or country fields of parsed records.
+//
+// Items of uncomparable types, e.g. slices, are kept as they are, so are
+// the items which can't be compared because of what they hold, e.g. a
+// struct with an interface field holding a map.
+//
+// Example:
+//   lines := New(FromReaderLines(r)).Intern()
+//   every "GET" line shares the same string data
+func (it *Iter) Intern() *Iter {
+	newitem := it.impl.newItem()
+	table := map[interface{}]interface{}{}
+
+	var n int
+	for {
+		elm, more := it.impl.next()
+		if !more {
+			break
+		}
+		newitem.Add(intern(table, elm))
+		n++
+	}
+	return newFromImpl(it.impl.derive("Intern", newitem, n, n))
+}
+
+// intern returns the item of table equal to elm, adding elm to it if
+// there is none. Hashing elm panics if it holds uncomparable values while
+// its type is comparable, elm is returned as it is then.
+func intern(table map[interface{}]interface{}, elm interface{}) (v interface{}) {
+	if elm == nil || !reflect.TypeOf(elm).Comparable() {
+		return elm
+	}
+	defer func() {
+		if recover() != nil {
+			v = elm
+		}
+	}()
+	if shared, ok := table[elm]; ok {
+		return shared
+	}
+	table[elm] = elm
+	return elm
+}
@@ -0,0 +1,65 @@
+package iter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestDedup(t *testing.T) {
+	it := New(FromStrings([]string{"a", "a", "b", "a", "a"}))
+	if got := it.Dedup().Collect(); fmt.Sprint(got) != "[a b a]" {
+		t.Errorf("Dedup got: %v, want: [a b a]", got)
+	}
+
+	it = New(FromStrings([]string{"a", "A", "b", "B", "c"}))
+	got := it.DedupBy(func(x, y interface{}) bool {
+		return strings.EqualFold(x.(string), y.(string))
+	}).Collect()
+	if fmt.Sprint(got) != "[a b c]" {
+		t.Errorf("DedupBy got: %v, want: [a b c]", got)
+	}
+
+	if got := New(FromStrings(nil)).Dedup().Count(); got != 0 {
+		t.Errorf("Dedup of nothing got %d items", got)
+	}
+}
+
+func TestUnique(t *testing.T) {
+	it := New(FromStrings([]string{"b", "a", "b", "c", "a"}))
+	if got := it.Unique(nil).Collect(); fmt.Sprint(got) != "[b a c]" {
+		t.Errorf("Unique got: %v, want: [b a c]", got)
+	}
+
+	it = New(FromStrings([]string{"apple", "banana", "avocado", "blueberry"}))
+	got := it.Unique(func(v interface{}) interface{} { return v.(string)[:1] }).Collect()
+	if fmt.Sprint(got) != "[apple banana]" {
+		t.Errorf("Unique by first letter got: %v, want: [apple banana]", got)
+	}
+}
+
+// stringData returns the address of the data of s.
+func stringData(s string) uintptr {
+	return (*[2]uintptr)(unsafe.Pointer(&s))[0]
+}
+
+func TestIntern(t *testing.T) {
+	a, b := strings.Repeat("x", 3), strings.Repeat("x", 3)
+	if stringData(a) == stringData(b) {
+		t.Skip("the strings share their data already")
+	}
+	src, _ := FromSlice([]interface{}{a, []int{1}, b, nil, 1})
+	got := New(src).Intern().Collect().([]interface{})
+	if fmt.Sprint(got) != "[xxx [1] xxx <nil> 1]" {
+		t.Errorf("Intern got: %v, want: [xxx [1] xxx <nil> 1]", got)
+	}
+	if stringData(got[0].(string)) != stringData(got[2].(string)) {
+		t.Error("Intern didn't share the data of the equal strings")
+	}
+	type field struct{ V interface{} }
+	src, _ = FromSlice([]interface{}{field{1}, field{map[string]int{}}, field{1}})
+	if got := New(src).Intern().Collect(); fmt.Sprint(got) != "[{1} {map[]} {1}]" {
+		t.Errorf("Intern on a struct holding a map got: %v, want: [{1} {map[]} {1}]", got)
+	}
+}
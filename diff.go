@@ -0,0 +1,284 @@
+package iter
+
+import "fmt"
+
+// EditOp is the kind of an Edit.
+type EditOp int
+
+const (
+	// EditEqual keeps an item which exists in both Iterables.
+	EditEqual EditOp = iota
+	// EditInsert adds an item which only exists in the second Iterable.
+	EditInsert
+	// EditDelete removes an item which only exists in the first Iterable.
+	EditDelete
+)
+
+// String implements the Stringer interface for EditOp.
+func (op EditOp) String() string {
+	switch op {
+	case EditEqual:
+		return "="
+	case EditInsert:
+		return "+"
+	case EditDelete:
+		return "-"
+	}
+	return fmt.Sprintf("EditOp(%d)", int(op))
+}
+
+// Edit is a single step of an edit script produced by Diff.
+//
+// A and B are the 0-based indexes of Value in the first and the second
+// Iterable respectively. A is -1 for an EditInsert and B is -1 for an
+// EditDelete since the item only exists on one side.
+type Edit struct {
+	Op    EditOp
+	Value interface{}
+	A     int
+	B     int
+}
+
+// String provides a stringify impl for Edit.
+func (e *Edit) String() string {
+	return fmt.Sprintf("%s%+v", e.Op, e.Value)
+}
+
+// EqualFunc reports whether two items are considered the same.
+type EqualFunc func(x, y interface{}) bool
+
+// Diff compares two Iterators and returns a new Iterator of *Edit which
+// turns the items of a into the items of b, using Myers' algorithm so the
+// edit script is a shortest one.
+//
+// Diff reads both Iterators entirely. Same as Each, an Iterable which is
+// also a Rewinder is rewinded afterwards.
+//
+// Example:
+//   a := New(FromStrings([]string{"a", "b", "c"}))
+//   b := New(FromStrings([]string{"a", "c", "d"}))
+//   edits := Diff(a, b, func(x, y interface{}) bool { return x == y })
+//   produces edits contains [=a -b =c +d]
+func Diff(a, b *Iter, eq EqualFunc) *Iter {
+	xs, ys := a.impl.slice(), b.impl.slice()
+
+	out, _ := newElems()
+	for _, e := range myers(xs, ys, eq) {
+		out.Add(e)
+	}
+	return New(out)
+}
+
+// LCS returns a new Iterator contains the longest common subsequence of
+// the two Iterators, that is, the longest sequence of items which appear
+// in both of them in the same relative order, though not necessarily
+// next to each other.
+//
+// The items are taken from a and the returned Iterator is created by a's
+// Iterable New API. Same as Diff, both Iterators are read entirely.
+//
+// Example:
+//   a := New(FromStrings([]string{"a", "b", "c", "d"}))
+//   b := New(FromStrings([]string{"b", "x", "d"}))
+//   LCS(a, b, func(x, y interface{}) bool { return x == y })
+//   produces []string{"b", "d"}
+func LCS(a, b *Iter, eq EqualFunc) *Iter {
+	newitem := a.impl.newItem()
+
+	xs := a.impl.slice()
+	var n int
+	for _, e := range myers(xs, b.impl.slice(), eq) {
+		if e.Op == EditEqual {
+			newitem.Add(e.Value)
+			n++
+		}
+	}
+	return newFromImpl(a.impl.derive("LCS", newitem, len(xs), n))
+}
+
+// FuzzyMatch returns a FilterFunc for string items which holds when the
+// edit distance between the item and query is at most maxDist.
+//
+// The distance is the Damerau-Levenshtein distance (optimal string
+// alignment variant) counted in runes: an insertion, a deletion, a
+// substitution or a transposition of two adjacent runes costs 1 each.
+//
+// Example:
+//   it := New(FromStrings([]string{"golang", "gloang", "python"}))
+//   newit := it.Filter(FuzzyMatch("golang", 1))
+//   produces a newit contains []string{"golang", "gloang"}
+func FuzzyMatch(query string, maxDist int) FilterFunc {
+	q := []rune(query)
+	return func(v interface{}) bool {
+		s := []rune(v.(string))
+		// The distance is at least the difference of the lengths.
+		if d := len(s) - len(q); d > maxDist || -d > maxDist {
+			return false
+		}
+		return editDistance(q, s) <= maxDist
+	}
+}
+
+// editDistance computes the optimal string alignment distance b/w a and b.
+func editDistance(a, b []rune) int {
+	// Only the last three rows of the matrix are needed.
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = minInt(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
+
+func minInt(n int, rest ...int) int {
+	for _, m := range rest {
+		if m < n {
+			n = m
+		}
+	}
+	return n
+}
+
+// myers returns the shortest edit script from xs to ys.
+func myers(xs, ys []interface{}, eq EqualFunc) []*Edit {
+	n, m := len(xs), len(ys)
+	max := n + m
+	offset := max + 1
+	v := make([]int, 2*max+3)
+	// trace keeps a copy of v for every d, so that the path can be
+	// recovered backwards once the end is reached.
+	var trace [][]int
+
+search:
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && eq(xs[x], ys[y]) {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	var script []*Edit
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			script = append(script, &Edit{EditEqual, xs[x], x, y})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			y--
+			script = append(script, &Edit{EditInsert, ys[y], -1, y})
+		} else {
+			x--
+			script = append(script, &Edit{EditDelete, xs[x], x, -1})
+		}
+	}
+
+	for i, j := 0, len(script)-1; i < j; i, j = i+1, j-1 {
+		script[i], script[j] = script[j], script[i]
+	}
+	return script
+}
+
+// Patch applies an edit script, typically produced by Diff, to the src
+// Iterator and returns a new Iterator of the patched items.
+//
+// Patch is lazy: nothing is read from src nor from edits until the
+// returned Iterator is traversed, and both are consumed in lockstep, one
+// *Edit at a time. EditEqual emits the next item of src, EditDelete skips
+// it and EditInsert emits the Value of the edit.
+//
+// The returned Iterable can only be traversed once and doesn't accept Add.
+//
+// Example:
+//   a := New(FromStrings([]string{"a", "b", "c"}))
+//   b := New(FromStrings([]string{"a", "c", "d"}))
+//   edits := Diff(a, b, func(x, y interface{}) bool { return x == y })
+//   Patch(a, edits) produces "a", "c", "d"
+func Patch(src *Iter, edits *Iter) *Iter {
+	return New(&patched{src: src.impl.item, edits: edits.impl.item})
+}
+
+// patched is the lazy Iterable behind Patch.
+type patched struct {
+	src   Iterable
+	edits Iterable
+}
+
+// Err returns the error of the source or of the edits, if any.
+func (p *patched) Err() error {
+	return errOf(p.src, p.edits)
+}
+
+func (*patched) New() (Iterable, error) {
+	return newElems()
+}
+
+func (*patched) Add(interface{}) {
+	panic("iter: Add is not supported by a patched Iterable")
+}
+
+func (p *patched) Next() (interface{}, bool) {
+	for {
+		elm, more := p.edits.Next()
+		if !more {
+			return nil, false
+		}
+		e := elm.(*Edit)
+		switch e.Op {
+		case EditInsert:
+			return e.Value, true
+		case EditEqual:
+			return p.src.Next()
+		case EditDelete:
+			p.src.Next()
+		default:
+			panic(fmt.Sprintf("iter: unknown EditOp %d", int(e.Op)))
+		}
+	}
+}
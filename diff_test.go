@@ -0,0 +1,105 @@
+package iter
+
+import (
+	"fmt"
+	"testing"
+)
+
+func eqItems(x, y interface{}) bool { return x == y }
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		desc string
+		a, b []string
+		want string
+	}{
+		{"same", []string{"a", "b"}, []string{"a", "b"}, "[=a =b]"},
+		{"empty", nil, nil, "[]"},
+		{"insert-all", nil, []string{"a", "b"}, "[+a +b]"},
+		{"delete-all", []string{"a", "b"}, nil, "[-a -b]"},
+		{"mixed", []string{"a", "b", "c"}, []string{"a", "c", "d"}, "[=a -b =c +d]"},
+		{"classic", []string{"a", "b", "c", "a", "b", "b", "a"}, []string{"c", "b", "a", "b", "a", "c"},
+			"[-a -b =c +b =a =b -b =a +c]"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			edits := Diff(New(FromStrings(tc.a)), New(FromStrings(tc.b)), eqItems)
+			if got := fmt.Sprint(edits.Collect()); got != tc.want {
+				t.Errorf("Diff(%v, %v) got: %s, want: %s", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLCS(t *testing.T) {
+	tests := []struct {
+		a, b []string
+		want string
+	}{
+		{[]string{"a", "b", "c", "d"}, []string{"b", "x", "d"}, "[b d]"},
+		{[]string{"a", "b"}, []string{"c"}, "[]"},
+		{nil, []string{"c"}, "[]"},
+		{[]string{"x", "y", "z"}, []string{"x", "y", "z"}, "[x y z]"},
+	}
+
+	for _, tc := range tests {
+		got := LCS(New(FromStrings(tc.a)), New(FromStrings(tc.b)), eqItems).Collect().([]string)
+		if fmt.Sprint(got) != tc.want {
+			t.Errorf("LCS(%v, %v) got: %v, want: %s", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		s    string
+		dist int
+	}{
+		{"golang", 0},
+		{"gloang", 1},
+		{"golan", 1},
+		{"goolang", 1},
+		{"gopher", 4},
+		{"", 6},
+		{"gölang", 1},
+	}
+
+	for _, tc := range tests {
+		for max := 0; max <= 6; max++ {
+			if got, want := FuzzyMatch("golang", max)(tc.s), tc.dist <= max; got != want {
+				t.Errorf("FuzzyMatch(\"golang\", %d)(%q) got: %t, want: %t", max, tc.s, got, want)
+			}
+		}
+	}
+
+	got := New(FromStrings([]string{"golang", "gloang", "python"})).
+		Filter(FuzzyMatch("golang", 1)).
+		Collect().([]string)
+	if fmt.Sprint(got) != "[golang gloang]" {
+		t.Errorf("Filter(FuzzyMatch) got: %v, want: [golang gloang]", got)
+	}
+}
+
+func TestPatch(t *testing.T) {
+	a := New(FromStrings([]string{"a", "b", "c", "a", "b", "b", "a"}))
+	b := New(FromStrings([]string{"c", "b", "a", "b", "a", "c"}))
+	edits := Diff(a, b, eqItems)
+
+	got := Patch(a, edits).Into(NewIterStrings(), func(v interface{}) (interface{}, error) {
+		return v, nil
+	}).Collect()
+	if fmt.Sprint(got) != "[c b a b a c]" {
+		t.Errorf("Patch got: %v, want: [c b a b a c]", got)
+	}
+
+	// Patch must not read anything until traversed.
+	src := New(FromStrings([]string{"x"}))
+	p := Patch(src, New(FromStrings(nil)))
+	if v, _ := src.impl.item.Next(); v != "x" {
+		t.Errorf("Patch read from its source before being traversed, source then yields: %v", v)
+	}
+	if _, more := p.impl.item.Next(); more {
+		t.Error("Patch with an empty edit script yields items")
+	}
+}
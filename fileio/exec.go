@@ -0,0 +1,36 @@
+package fileio
+
+import (
+	"context"
+	"os/exec"
+
+	"github.com/i3d/goiter/internal/command"
+	"github.com/i3d/goiter/internal/readsrc"
+)
+
+// FromCommand starts cmd and creates a Source of the lines of its
+// standard output, read lazily, so that a CLI tool can feed a pipeline
+// without any pipe plumbing. cmd must not have its Stdout set.
+//
+// Once the output is read until its end, the command is waited for and
+// its exit error, e.g. an *exec.ExitError for a non-zero exit status, is
+// returned by Err. The command is killed when ctx is done, Err returns
+// then the error of ctx, or when the Source is closed before its end.
+//
+// Example:
+//   src, err := fileio.FromCommand(ctx, exec.Command("git", "log", "--format=%an"))
+//   if err != nil {
+//      return err
+//   }
+//   authors := iter.New(src).Dedup()
+//   ...
+//   if err := src.Err(); err != nil {
+//      return err
+//   }
+func FromCommand(ctx context.Context, cmd *exec.Cmd) (*Source, error) {
+	c, err := command.Start(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return &Source{readsrc.New("fileio", "a command", c, c.Read)}, nil
+}
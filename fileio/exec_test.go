@@ -0,0 +1,51 @@
+package fileio
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+
+	iter "github.com/i3d/goiter"
+)
+
+func TestFromCommand(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no sh:", err)
+	}
+
+	src, err := FromCommand(context.Background(), exec.Command("sh", "-c", "echo a; echo b; exit 3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []interface{}
+	iter.New(src).Each(func(v interface{}) { got = append(got, v) })
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("FromCommand got: %v, want: [a b]", got)
+	}
+	var exit *exec.ExitError
+	if !errors.As(src.Err(), &exit) || exit.ExitCode() != 3 {
+		t.Errorf("FromCommand got error: %v, want: exit status 3", src.Err())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	src, err = FromCommand(ctx, exec.Command("sh", "-c", "echo a; exec sleep 10"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, more := src.Next(); !more || v != "a" {
+		t.Fatalf("FromCommand got: %v, %v, want: a, true", v, more)
+	}
+	cancel()
+	if _, more := src.Next(); more || !errors.Is(src.Err(), context.Canceled) {
+		t.Errorf("FromCommand after cancel got more: %v, error: %v, want: %v", more, src.Err(), context.Canceled)
+	}
+
+	src, err = FromCommand(context.Background(), exec.Command("sh", "-c", "echo a; exec sleep 10"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Close(); err != nil {
+		t.Errorf("FromCommand Close got error: %v", err)
+	}
+}
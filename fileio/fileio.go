@@ -0,0 +1,291 @@
+// Package fileio reads and writes files of lines, CSV records or
+// newline delimited JSON values as Iterables of the github.com/i3d/goiter
+// package, transparently decompressing and compressing them according
+// to the extension of the file name, e.g. "access.log.gz".
+//
+// Gzip (".gz") is built in. As the standard library has no zstd support
+// and goiter keeps free of external dependencies, other formats such as
+// zstd (".zst") are plugged in by RegisterCodec with a few lines of shim
+// around the library of your choice.
+package fileio
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	iter "github.com/i3d/goiter"
+	"github.com/i3d/goiter/internal/readsrc"
+)
+
+// Codec decompresses and compresses a file format.
+type Codec struct {
+	// Reader returns a reader of the decompressed content of r.
+	Reader func(r io.Reader) (io.ReadCloser, error)
+	// Writer returns a writer compressing into w. Closing it flushes
+	// the compressed content but doesn't close w.
+	Writer func(w io.Writer) (io.WriteCloser, error)
+}
+
+var (
+	mu     sync.RWMutex
+	codecs = map[string]Codec{
+		".gz": {
+			Reader: func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) },
+			Writer: func(w io.Writer) (io.WriteCloser, error) { return gzip.NewWriter(w), nil },
+		},
+	}
+)
+
+// RegisterCodec makes Open and Create handle the file names ending with
+// ext, e.g. ".zst", through c, replacing the Codec registered for ext
+// if any.
+//
+// Example (with github.com/klauspost/compress/zstd):
+//   fileio.RegisterCodec(".zst", fileio.Codec{
+//      Reader: func(r io.Reader) (io.ReadCloser, error) {
+//         d, err := zstd.NewReader(r)
+//         if err != nil {
+//            return nil, err
+//         }
+//         return d.IOReadCloser(), nil
+//      },
+//      Writer: func(w io.Writer) (io.WriteCloser, error) { return zstd.NewWriter(w) },
+//   })
+func RegisterCodec(ext string, c Codec) {
+	mu.Lock()
+	defer mu.Unlock()
+	codecs[ext] = c
+}
+
+// codecOf returns the Codec of the extension of the file name path,
+// the bool tells whether there is one.
+func codecOf(path string) (Codec, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	c, ok := codecs[filepath.Ext(path)]
+	return c, ok
+}
+
+// Open opens the file path for reading, decompressed if its extension
+// has a Codec.
+func Open(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := codecOf(path)
+	if !ok {
+		return f, nil
+	}
+	r, err := c.Reader(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("fileio: opening %s: %w", path, err)
+	}
+	return readCloser{r, closers{r, f}}, nil
+}
+
+// Create creates or truncates the file path for writing, compressed if
+// its extension has a Codec.
+func Create(path string) (io.WriteCloser, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := codecOf(path)
+	if !ok {
+		return f, nil
+	}
+	w, err := c.Writer(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("fileio: creating %s: %w", path, err)
+	}
+	return writeCloser{w, closers{w, f}}, nil
+}
+
+// closers closes a stack of Closers in order, e.g. a gzip.Reader then
+// its file, and returns the first error.
+type closers []io.Closer
+
+func (cs closers) Close() error {
+	var first error
+	for _, c := range cs {
+		if err := c.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+type readCloser struct {
+	io.Reader
+	closers
+}
+
+type writeCloser struct {
+	io.Writer
+	closers
+}
+
+// Source is an Iterable of the records of a file, read lazily. The file
+// is closed once read until its end, Close closes it before.
+//
+// Next reports no more items on an error, e.g. a malformed record, which
+// is then returned by Err. The Iterables created by New are in-memory
+// Rewinders.
+type Source struct {
+	*readsrc.Source
+}
+
+// newSource opens path for a Source reading its records by read.
+func newSource(path string, read func(io.Reader) func() (interface{}, error)) (*Source, error) {
+	f, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return fileSource(f, read(f)), nil
+}
+
+// fileSource creates a Source reading f by read.
+func fileSource(f io.Closer, read func() (interface{}, error)) *Source {
+	return &Source{readsrc.New("fileio", "a file", f, read)}
+}
+
+// Lines creates a Source of the lines of the file path, as strings
+// without their line terminator.
+//
+// Example:
+//   src, err := fileio.Lines("access.log.gz")
+//   if err != nil {
+//      return err
+//   }
+//   errs := iter.New(src).Filter(isError)
+func Lines(path string) (*Source, error) {
+	return newSource(path, lines)
+}
+
+// lines reads the lines of r, as strings without their line terminator.
+func lines(r io.Reader) func() (interface{}, error) {
+	sc := bufio.NewScanner(r)
+	return func() (interface{}, error) {
+		if !sc.Scan() {
+			if err := sc.Err(); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+		return sc.Text(), nil
+	}
+}
+
+// CSV creates a Source of the records of the CSV file path, as []string.
+func CSV(path string) (*Source, error) {
+	return newSource(path, func(r io.Reader) func() (interface{}, error) {
+		cr := csv.NewReader(r)
+		return func() (interface{}, error) {
+			return cr.Read()
+		}
+	})
+}
+
+// NDJSON creates a Source of the values of the newline delimited JSON
+// file path, every one decoded by decode. A nil decode decodes into an
+// interface{}, e.g. a map[string]interface{} for a JSON object.
+//
+// Example:
+//   src, err := fileio.NDJSON("events.ndjson.zst", func(b []byte) (interface{}, error) {
+//      var e Event
+//      err := json.Unmarshal(b, &e)
+//      return e, err
+//   })
+func NDJSON(path string, decode func([]byte) (interface{}, error)) (*Source, error) {
+	if decode == nil {
+		decode = func(b []byte) (interface{}, error) {
+			var v interface{}
+			err := json.Unmarshal(b, &v)
+			return v, err
+		}
+	}
+	return newSource(path, func(r io.Reader) func() (interface{}, error) {
+		dec := json.NewDecoder(r)
+		return func() (interface{}, error) {
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return nil, err
+			}
+			return decode(raw)
+		}
+	})
+}
+
+// write creates the file path and writes every item of it by write.
+// Same as Each, the Iterable is rewinded afterwards if it is a Rewinder.
+func write(it *iter.Iter, path string, write func(w io.Writer, v interface{}) error, flush func() error) error {
+	f, err := Create(path)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(f)
+	it.Each(func(v interface{}) {
+		if err == nil {
+			err = write(bw, v)
+		}
+	})
+	if err == nil && flush != nil {
+		err = flush()
+	}
+	if ferr := bw.Flush(); err == nil {
+		err = ferr
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// WriteLines writes every item of it as a line of the file path,
+// compressed if its extension has a Codec. An item which isn't a string
+// is written in its %v format.
+//
+// Example:
+//   err := fileio.WriteLines(it, "out.log.gz")
+func WriteLines(it *iter.Iter, path string) error {
+	return write(it, path, func(w io.Writer, v interface{}) error {
+		_, err := fmt.Fprintln(w, v)
+		return err
+	}, nil)
+}
+
+// WriteCSV writes every item of it, a []string, as a record of the CSV
+// file path, compressed if its extension has a Codec.
+func WriteCSV(it *iter.Iter, path string) error {
+	var cw *csv.Writer
+	return write(it, path, func(w io.Writer, v interface{}) error {
+		if cw == nil {
+			cw = csv.NewWriter(w)
+		}
+		return cw.Write(v.([]string))
+	}, func() error {
+		if cw == nil {
+			return nil
+		}
+		cw.Flush()
+		return cw.Error()
+	})
+}
+
+// WriteNDJSON writes every item of it as a JSON value on its own line of
+// the file path, compressed if its extension has a Codec.
+func WriteNDJSON(it *iter.Iter, path string) error {
+	return write(it, path, func(w io.Writer, v interface{}) error {
+		return json.NewEncoder(w).Encode(v)
+	}, nil)
+}
@@ -0,0 +1,131 @@
+package fileio
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	iter "github.com/i3d/goiter"
+)
+
+// upper is a Codec storing the content upper-cased, so that the tests can
+// tell it has been applied.
+var upper = Codec{
+	Reader: func(r io.Reader) (io.ReadCloser, error) {
+		b, err := io.ReadAll(r)
+		return io.NopCloser(bytes.NewReader(bytes.ToLower(b))), err
+	},
+	Writer: func(w io.Writer) (io.WriteCloser, error) {
+		return &upperWriter{w: w}, nil
+	},
+}
+
+type upperWriter struct {
+	w   io.Writer
+	buf bytes.Buffer
+}
+
+func (u *upperWriter) Write(p []byte) (int, error) {
+	return u.buf.Write(p)
+}
+
+func (u *upperWriter) Close() error {
+	_, err := u.w.Write(bytes.ToUpper(u.buf.Bytes()))
+	return err
+}
+
+func collect(t *testing.T, src *Source, err error) string {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out []interface{}
+	iter.New(src).Each(func(v interface{}) { out = append(out, v) })
+	if src.Err() != nil {
+		t.Fatal(src.Err())
+	}
+	return fmt.Sprint(out)
+}
+
+func TestRoundTrip(t *testing.T) {
+	RegisterCodec(".up", upper)
+	dir := t.TempDir()
+	lines := []string{"a,b", "c,d"}
+
+	for _, name := range []string{"plain.txt", "lines.gz", "lines.up"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+
+			if err := WriteLines(iter.New(iter.FromStrings(lines)), path); err != nil {
+				t.Fatal(err)
+			}
+			src, err := Lines(path)
+			if got := collect(t, src, err); got != "[a,b c,d]" {
+				t.Errorf("Lines got: %s, want: [a,b c,d]", got)
+			}
+
+			src, err = CSV(path)
+			if got := collect(t, src, err); got != "[[a b] [c d]]" {
+				t.Errorf("CSV got: %s, want: [[a b] [c d]]", got)
+			}
+			if src, err = CSV(path); err != nil {
+				t.Fatal(err)
+			}
+			swap := iter.NewPipeline().Map(func(v interface{}) interface{} {
+				r := v.([]string)
+				return []string{r[1], r[0]}
+			})
+			swapped := filepath.Join(dir, "swapped-"+name)
+			if err := WriteCSV(swap.Run(src), swapped); err != nil {
+				t.Fatal(err)
+			}
+			src, err = Lines(swapped)
+			if got := collect(t, src, err); got != "[b,a d,c]" {
+				t.Errorf("WriteCSV got: %s, want: [b,a d,c]", got)
+			}
+		})
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, "swapped-lines.up"))
+	if err != nil || string(raw) != "B,A\nD,C\n" {
+		t.Errorf("the registered Codec wrote: %q, %v", raw, err)
+	}
+	f, _ := os.Open(filepath.Join(dir, "lines.gz"))
+	defer f.Close()
+	if _, err := gzip.NewReader(f); err != nil {
+		t.Errorf("a .gz file isn't gzipped: %v", err)
+	}
+}
+
+func TestNDJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.ndjson.gz")
+	in := []string{"a", "b"}
+	named := iter.NewPipeline().Map(func(v interface{}) interface{} {
+		return map[string]string{"name": v.(string)}
+	})
+	if err := WriteNDJSON(named.Run(iter.FromStrings(in)), path); err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := NDJSON(path, nil)
+	if got := collect(t, src, err); got != "[map[name:a] map[name:b]]" {
+		t.Errorf("NDJSON got: %s", got)
+	}
+
+	src, err = NDJSON(path, func(b []byte) (interface{}, error) {
+		return nil, fmt.Errorf("bad %s", b)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := iter.New(src).Count(); n != 0 || src.Err() == nil {
+		t.Errorf("NDJSON with a failing decode got %d items with error: %v", n, src.Err())
+	}
+	if _, err := Lines(filepath.Join(t.TempDir(), "missing.gz")); err == nil {
+		t.Error("Lines of a missing file got no error")
+	}
+}
@@ -0,0 +1,125 @@
+package fileio
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// FollowOptions configures Follow.
+type FollowOptions struct {
+	// Poll is the interval between two checks for new lines, one second
+	// if zero.
+	Poll time.Duration
+	// FromStart reads the lines already in the file first, otherwise
+	// only the lines appended after Follow are read.
+	FromStart bool
+}
+
+// Follow creates a Source of the lines appended to the file path, like
+// tail -f, e.g. as the front end of a log-follower pipeline. The file is
+// polled for new lines, so Next waits until a complete line is appended.
+//
+// A file truncated or replaced, e.g. by a log rotation, is read again
+// from its start. The Source ends, without any error, once ctx is done.
+// Compressed files are not supported.
+//
+// Example:
+//   src, err := fileio.Follow(ctx, "/var/log/app.log", fileio.FollowOptions{})
+//   if err != nil {
+//      return err
+//   }
+//   iter.New(src).ParEach(4, alert)
+func Follow(ctx context.Context, path string, opts FollowOptions) (*Source, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	fl := &follower{ctx: ctx, path: path, poll: opts.Poll, f: f, r: bufio.NewReader(f)}
+	if fl.poll == 0 {
+		fl.poll = time.Second
+	}
+	if !opts.FromStart {
+		if fl.offset, err = f.Seek(0, io.SeekEnd); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	return fileSource(fl, fl.read), nil
+}
+
+// follower reads the lines appended to a file.
+type follower struct {
+	ctx  context.Context
+	path string
+	poll time.Duration
+
+	f       *os.File
+	r       *bufio.Reader
+	offset  int64
+	partial string
+}
+
+func (fl *follower) read() (interface{}, error) {
+	for {
+		line, err := fl.r.ReadString('\n')
+		fl.offset += int64(len(line))
+		fl.partial += line
+		if err == nil {
+			line, fl.partial = strings.TrimRight(fl.partial, "\r\n"), ""
+			return line, nil
+		}
+		if err != io.EOF {
+			return nil, err
+		}
+
+		if err := fl.check(); err != nil {
+			return nil, err
+		}
+		select {
+		case <-fl.ctx.Done():
+			return nil, io.EOF
+		case <-time.After(fl.poll):
+		}
+	}
+}
+
+// check reads the file again from its start when it has been truncated
+// or replaced. A missing file, e.g. in the middle of a rotation, is
+// checked again later.
+func (fl *follower) check() error {
+	st, err := os.Stat(fl.path)
+	if err != nil {
+		return nil
+	}
+	cur, err := fl.f.Stat()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !os.SameFile(st, cur):
+		f, err := os.Open(fl.path)
+		if err != nil {
+			return nil
+		}
+		fl.f.Close()
+		fl.f = f
+	case st.Size() < fl.offset:
+		if _, err := fl.f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+	fl.r.Reset(fl.f)
+	fl.offset, fl.partial = 0, ""
+	return nil
+}
+
+func (fl *follower) Close() error {
+	return fl.f.Close()
+}
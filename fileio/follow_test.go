@@ -0,0 +1,70 @@
+package fileio
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFollow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte("old\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	appendLine := func(s string) {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer f.Close()
+		f.WriteString(s)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	src, err := Follow(ctx, path, FollowOptions{Poll: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go appendLine("a\nb")
+	next := func(want string) {
+		t.Helper()
+		if v, more := src.Next(); !more || v != want {
+			t.Fatalf("Follow got: %v, %v, want: %s, true", v, more, want)
+		}
+	}
+	next("a")
+	go appendLine("c\n")
+	next("bc")
+
+	// A truncated file is read from its start.
+	if err := os.WriteFile(path, []byte("d\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	next("d")
+
+	// So is a replaced one.
+	if err := os.WriteFile(path+".new", []byte("e\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(path+".new", path); err != nil {
+		t.Fatal(err)
+	}
+	next("e")
+
+	cancel()
+	if _, more := src.Next(); more || src.Err() != nil {
+		t.Errorf("Follow after cancel got more: %v, error: %v", more, src.Err())
+	}
+
+	src, err = Follow(context.Background(), path, FollowOptions{FromStart: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+	next("e")
+}
@@ -0,0 +1,242 @@
+package iter
+
+import "math"
+
+// Range creates a lazy Iterable of the ints from start up to stop
+// excluded, by step, like Python's range: a negative step counts down
+// and a zero step produces no item. The ints are computed as they are
+// read, so a numeric pipeline doesn't need to allocate a slice first.
+//
+// The Iterable is an Enumerator, a Rewinder, a Lener, a Seeker and a
+// FromIter. It doesn't accept Add, its New API creates an IterInts.
+//
+// Example:
+//   New(Range(0, 10, 3)) produces 0, 3, 6, 9
+//   New(Range(3, 0, -1)) produces 3, 2, 1
+func Range(start, stop, step int) Iterable {
+	n := 0
+	if step > 0 && stop > start {
+		n = (stop - start + step - 1) / step
+	} else if step < 0 && stop < start {
+		n = (start - stop - step - 1) / -step
+	}
+	return &ranged{n: n, idx: -1, at: func(i int) interface{} { return start + i*step }, new: func() Iterable { return NewIterInts() }}
+}
+
+// RangeFloat is like Range for float64s. Every item is computed as
+// start + i*step, so no rounding error accumulates.
+//
+// Example:
+//   New(RangeFloat(0, 1, 0.25)) produces 0, 0.25, 0.5, 0.75
+func RangeFloat(start, stop, step float64) Iterable {
+	n := 0
+	if d := (stop - start) / step; d > 0 && !math.IsInf(d, 0) {
+		n = int(math.Ceil(d))
+	}
+	return &ranged{n: n, idx: -1, at: func(i int) interface{} { return start + float64(i)*step }, new: func() Iterable { return NewIterFloats() }}
+}
+
+// Repeat creates an infinite Iterable of v, e.g. to Zip a constant with
+// every item of another Iterable. It doesn't accept Add, its New API
+// creates an in-memory Iterable.
+//
+// Example:
+//   New(FromStrings([]string{"a", "b"})).Zip(Repeat(0))
+//   produces {a, 0}, {b, 0}
+func Repeat(v interface{}) Iterable {
+	return repeated{v}
+}
+
+// RepeatN is like Repeat but stops after n items. Same as Range, the
+// Iterable is an Enumerator, a Rewinder, a Lener, a Seeker and a FromIter.
+//
+// Example:
+//   New(RepeatN("x", 3)) produces "x", "x", "x"
+func RepeatN(v interface{}, n int) Iterable {
+	if n < 0 {
+		n = 0
+	}
+	return &ranged{n: n, idx: -1, at: func(int) interface{} { return v }, new: func() Iterable {
+		es, _ := newElems()
+		return es
+	}}
+}
+
+// Once creates an Iterable of the single item v.
+func Once(v interface{}) Iterable {
+	return RepeatN(v, 1)
+}
+
+// Empty creates an Iterable without any item.
+func Empty() Iterable {
+	return RepeatN(nil, 0)
+}
+
+// repeated is the Iterable behind Repeat.
+type repeated struct {
+	v interface{}
+}
+
+func (repeated) New() (Iterable, error) {
+	return newElems()
+}
+
+func (repeated) Add(interface{}) {
+	panic("iter: Add is not supported by a repeated Iterable")
+}
+
+func (r repeated) Next() (interface{}, bool) {
+	return r.v, true
+}
+
+// Rewind is a no-op, so that Repeat is reusable.
+func (repeated) Rewind() {}
+
+// FromFunc creates a lazy Iterable of the items returned by f, called
+// for every Next until its bool is false, e.g. for an infinite or a
+// computed source such as a counter, a random stream or a polling loop.
+// f isn't called anymore once it has reported no more items.
+//
+// The Iterable can only be traversed once and doesn't accept Add. Its
+// New API creates an in-memory Iterable for the outcome of the adapters.
+//
+// Example:
+//   n := 0
+//   New(FromFunc(func() (interface{}, bool) {
+//      n++
+//      return n * n, n <= 3
+//   })) produces 1, 4, 9
+func FromFunc(f func() (interface{}, bool)) Iterable {
+	return &funcSource{f: f}
+}
+
+// funcSource is the Iterable behind FromFunc.
+type funcSource struct {
+	f    func() (interface{}, bool)
+	done bool
+}
+
+func (*funcSource) New() (Iterable, error) {
+	return newElems()
+}
+
+func (*funcSource) Add(interface{}) {
+	panic("iter: Add is not supported by a func Iterable")
+}
+
+func (fs *funcSource) Next() (interface{}, bool) {
+	if fs.done {
+		return nil, false
+	}
+	v, more := fs.f()
+	if !more {
+		fs.done = true
+		return nil, false
+	}
+	return v, true
+}
+
+// Successors creates a lazy Iterable starting with seed, every next item
+// being f of the previous one, until f reports no more items, like Rust's
+// successors, e.g. for an exponential backoff schedule.
+//
+// The Iterable is a Rewinder which starts again from seed. It doesn't
+// accept Add, its New API creates an in-memory Iterable.
+//
+// Example:
+//   New(Successors(time.Second, func(v interface{}) (interface{}, bool) {
+//      d := 2 * v.(time.Duration)
+//      return d, d <= 8*time.Second
+//   })) produces 1s, 2s, 4s, 8s
+func Successors(seed interface{}, f func(interface{}) (interface{}, bool)) Iterable {
+	return &successors{seed: seed, f: f}
+}
+
+// successors is the Iterable behind Successors.
+type successors struct {
+	seed    interface{}
+	f       func(interface{}) (interface{}, bool)
+	cur     interface{}
+	started bool
+	done    bool
+}
+
+func (*successors) New() (Iterable, error) {
+	return newElems()
+}
+
+func (*successors) Add(interface{}) {
+	panic("iter: Add is not supported by a successors Iterable")
+}
+
+func (s *successors) Next() (interface{}, bool) {
+	switch {
+	case s.done:
+		return nil, false
+	case !s.started:
+		s.started, s.cur = true, s.seed
+	default:
+		v, more := s.f(s.cur)
+		if !more {
+			s.done = true
+			return nil, false
+		}
+		s.cur = v
+	}
+	return s.cur, true
+}
+
+func (s *successors) Rewind() {
+	s.cur, s.started, s.done = nil, false, false
+}
+
+// ranged is the Iterable behind Range and RangeFloat, at computes the
+// item at an index below n.
+type ranged struct {
+	n, idx int
+	at     func(int) interface{}
+	new    func() Iterable
+}
+
+func (r *ranged) New() (Iterable, error) {
+	return r.new(), nil
+}
+
+func (*ranged) Add(interface{}) {
+	panic("iter: Add is not supported by a range Iterable")
+}
+
+func (r *ranged) Next() (interface{}, bool) {
+	_, v, more := r.Enumerate()
+	return v, more
+}
+
+func (r *ranged) Enumerate() (int, interface{}, bool) {
+	r.idx++
+	if r.idx < r.n {
+		return r.idx, r.at(r.idx), true
+	}
+	return -1, nil, false
+}
+
+func (r *ranged) Rewind() {
+	r.idx = -1
+}
+
+func (r *ranged) Len() int {
+	return r.n
+}
+
+func (r *ranged) Seek(i int) {
+	r.idx = i - 1
+}
+
+// To materializes every item into the Iterable created by New, so that
+// Collect returns e.g. a []int for Range.
+func (r *ranged) To() interface{} {
+	out := r.new()
+	for i := 0; i < r.n; i++ {
+		out.Add(r.at(i))
+	}
+	return out.(FromIter).To()
+}
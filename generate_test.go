@@ -0,0 +1,98 @@
+package iter
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRange(t *testing.T) {
+	tests := []struct {
+		src  Iterable
+		want string
+	}{
+		{Range(0, 10, 3), "[0 3 6 9]"},
+		{Range(0, 9, 3), "[0 3 6]"},
+		{Range(3, 0, -1), "[3 2 1]"},
+		{Range(0, 3, -1), "[]"},
+		{Range(0, 3, 0), "[]"},
+		{RangeFloat(0, 1, 0.25), "[0 0.25 0.5 0.75]"},
+		{RangeFloat(1, 0, -0.5), "[1 0.5]"},
+		{RangeFloat(0, 1, 0), "[]"},
+	}
+
+	for _, tc := range tests {
+		var got []interface{}
+		New(tc.src).Each(func(v interface{}) { got = append(got, v) })
+		if s := fmt.Sprint(got); s != tc.want {
+			t.Errorf("%T got: %s, want: %s", tc.src, s, tc.want)
+		}
+		if n := tc.src.(Lener).Len(); n != len(got) {
+			t.Errorf("Len got: %d, want: %d", n, len(got))
+		}
+	}
+
+	evens := New(Range(0, 10, 1)).Filter(func(v interface{}) bool { return v.(int)%2 == 0 })
+	if got := evens.Collect().([]int); fmt.Sprint(got) != "[0 2 4 6 8]" {
+		t.Errorf("Range then Filter got: %v, want: [0 2 4 6 8]", got)
+	}
+}
+
+func TestRepeat(t *testing.T) {
+	zipped := New(FromStrings([]string{"a", "b"})).Zip(Repeat(0)).Collect()
+	if got := fmt.Sprint(zipped); got != "[{a, 0} {b, 0}]" {
+		t.Errorf("Zip with Repeat got: %s, want: [{a, 0} {b, 0}]", got)
+	}
+
+	tests := []struct {
+		desc string
+		src  Iterable
+		want string
+	}{
+		{"RepeatN", RepeatN("x", 3), "[x x x]"},
+		{"RepeatN-negative", RepeatN("x", -1), "[]"},
+		{"Once", Once(42), "[42]"},
+		{"Empty", Empty(), "[]"},
+	}
+	for _, tc := range tests {
+		it := New(tc.src)
+		if got := fmt.Sprint(it.Collect()); got != tc.want {
+			t.Errorf("%s got: %s, want: %s", tc.desc, got, tc.want)
+		}
+		it.Rewind()
+		if got := fmt.Sprint(it.Map(func(v interface{}) interface{} { return v }).Collect()); got != tc.want {
+			t.Errorf("%s then Map got: %s, want: %s", tc.desc, got, tc.want)
+		}
+	}
+}
+
+func TestFromFunc(t *testing.T) {
+	calls := 0
+	src := FromFunc(func() (interface{}, bool) {
+		calls++
+		return calls * calls, calls <= 3
+	})
+	got := New(src).Filter(func(v interface{}) bool { return v.(int) > 1 }).Collect()
+	if fmt.Sprint(got) != "[4 9]" {
+		t.Errorf("FromFunc got: %v, want: [4 9]", got)
+	}
+	if _, more := src.Next(); more || calls != 4 {
+		t.Errorf("FromFunc after its end got more: %v, calls: %d, want: false, 4", more, calls)
+	}
+}
+
+func TestSuccessors(t *testing.T) {
+	fib := Successors(&Pair{0, 1}, func(v interface{}) (interface{}, bool) {
+		p := v.(*Pair)
+		next := &Pair{p.Y, p.X.(int) + p.Y.(int)}
+		return next, next.X.(int) < 20
+	})
+	it := New(fib).Map(func(v interface{}) interface{} { return v.(*Pair).X })
+	if got := fmt.Sprint(it.Collect()); got != "[0 1 1 2 3 5 8 13]" {
+		t.Errorf("Successors got: %s, want: [0 1 1 2 3 5 8 13]", got)
+	}
+
+	fib.(Rewinder).Rewind()
+	if v, more := fib.Next(); !more || v.(*Pair).X != 0 {
+		t.Errorf("Successors after Rewind got: %v, %v, want: {0, 1}, true", v, more)
+	}
+}
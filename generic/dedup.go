@@ -0,0 +1,80 @@
+package generic
+
+// Dedup removes the consecutive repeated items of it, keeping the first
+// one of every run, and returns a new Iterator contains the rest.
+// See DedupBy for the items of uncomparable types.
+//
+// Example:
+//   newit := Dedup(New[string](FromSlice([]string{"a", "a", "b", "a"})))
+//   produces a newit contains []string{"a", "b", "a"}
+func Dedup[T comparable](it *Iter[T]) *Iter[T] {
+	return it.DedupBy(func(x, y T) bool { return x == y })
+}
+
+// DedupBy is like Dedup but considers two consecutive items the same
+// when eq returns true given the first item of the run and the item.
+func (it *Iter[T]) DedupBy(eq func(x, y T) bool) *Iter[T] {
+	newitem := it.newItem()
+
+	var last T
+	var emitted int
+	for {
+		elm, more := it.item.Next()
+		if !more {
+			break
+		}
+		if emitted > 0 && eq(last, elm) {
+			continue
+		}
+		newitem.Add(elm)
+		last = elm
+		emitted++
+	}
+	return derive[T, T](it, newitem)
+}
+
+// Unique keeps the first item of every key and returns a new Iterator
+// contains them, in their original order.
+//
+// Example:
+//   it := New[string](FromSlice([]string{"apple", "banana", "avocado"}))
+//   newit := Unique(it, func(v string) byte { return v[0] })
+//   produces a newit contains []string{"apple", "banana"}
+func Unique[T any, K comparable](it *Iter[T], key func(T) K) *Iter[T] {
+	newitem := it.newItem()
+	seen := map[K]struct{}{}
+	for {
+		elm, more := it.item.Next()
+		if !more {
+			break
+		}
+		k := key(elm)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		newitem.Add(elm)
+	}
+	return derive[T, T](it, newitem)
+}
+
+// Intern returns a new Iterator contains the items of it, every duplicate
+// being replaced by the first item equal to it, so that they share one
+// instance, e.g. the backing array of a string.
+func Intern[T comparable](it *Iter[T]) *Iter[T] {
+	newitem := it.newItem()
+	table := map[T]T{}
+	for {
+		elm, more := it.item.Next()
+		if !more {
+			break
+		}
+		if shared, ok := table[elm]; ok {
+			elm = shared
+		} else {
+			table[elm] = elm
+		}
+		newitem.Add(elm)
+	}
+	return derive[T, T](it, newitem)
+}
@@ -0,0 +1,82 @@
+package generic
+
+// GroupBy groups the items of it by their key and returns a new Iterator
+// of Pair, one per key in the order the keys are first seen: X is the key
+// and Y is an Iter of the items sharing it, in their original order.
+//
+// Example:
+//   it := New[string](FromSlice([]string{"apple", "banana", "avocado"}))
+//   groups := GroupBy(it, func(v string) byte { return v[0] })
+//   produces {'a', [apple avocado]} and {'b', [banana]}
+func GroupBy[T any, K comparable](it *Iter[T], key func(T) K) *Iter[Pair[K, *Iter[T]]] {
+	groups := NewSlice[Pair[K, *Iter[T]]]()
+	index := map[K]Iterable[T]{}
+	for {
+		elm, more := it.item.Next()
+		if !more {
+			break
+		}
+		k := key(elm)
+		group, ok := index[k]
+		if !ok {
+			group = it.newItem()
+			index[k] = group
+			groups.Add(Pair[K, *Iter[T]]{k, derive[T, T](it, group)})
+		}
+		group.Add(elm)
+	}
+	return derive[T, Pair[K, *Iter[T]]](it, groups)
+}
+
+// Stateful runs step against every item of it with a state, which is
+// created by init, and returns a new Iterator of the outputs of step,
+// backed by a Slice. step returns the state for the next item and the
+// output for this one.
+//
+// Example (a running count of distinct items):
+//   it := New[string](FromSlice([]string{"a", "b", "a"}))
+//   newit := Stateful(it,
+//      func() map[string]bool { return map[string]bool{} },
+//      func(seen map[string]bool, v string) (map[string]bool, int) {
+//         seen[v] = true
+//         return seen, len(seen)
+//      })
+//   produces a newit contains []int{1, 2, 2}
+func Stateful[T, S, U any](it *Iter[T], init func() S, step func(state S, v T) (S, U)) *Iter[U] {
+	return StatefulBy(it, func(T) struct{} { return struct{}{} }, init, step)
+}
+
+// Scan is like Stateful but starts with the state init, e.g. for running
+// totals.
+//
+// Example:
+//   it := New[string](FromSlice([]string{"a", "bb", "ccc"}))
+//   newit := Scan(it, 0, func(total int, v string) (int, int) {
+//      return total + len(v), total + len(v)
+//   })
+//   produces a newit contains []int{1, 3, 6}
+func Scan[T, S, U any](it *Iter[T], init S, f func(state S, v T) (S, U)) *Iter[U] {
+	return Stateful(it, func() S { return init }, f)
+}
+
+// StatefulBy is like Stateful but keeps a separate state for every key,
+// e.g. per-user counters. init is called the first time a key is seen.
+func StatefulBy[T any, K comparable, S, U any](it *Iter[T], key func(T) K, init func() S, step func(state S, v T) (S, U)) *Iter[U] {
+	newitem := NewSlice[U]()
+	states := map[K]S{}
+	for {
+		elm, more := it.item.Next()
+		if !more {
+			break
+		}
+		k := key(elm)
+		state, ok := states[k]
+		if !ok {
+			state = init()
+		}
+		state, out := step(state, elm)
+		states[k] = state
+		newitem.Add(out)
+	}
+	return derive[T, U](it, newitem)
+}
@@ -0,0 +1,431 @@
+// Package generic implements the Iterable API of the
+// github.com/i3d/goiter package with Go's type parameters.
+//
+// The APIs mirror the interface{} based ones, except that the
+// items are typed, so there is no boxing into interface{} nor
+// any type assertion. APIs changing the item type, e.g. Map
+// from T to U, can't be methods in Go, so they are provided as
+// functions taking the Iter as their first argument, whereas
+// Iter.Map maps T to T. So are the APIs needing a constraint
+// on T, e.g. Dedup of comparable items.
+//
+// The package covers the in-memory adapters and terminals of
+// the parent package. The APIs built on its interface{} based
+// machinery, i.e. the lazy Pipelines, the stream and I/O
+// sources, the parallel APIs, the strict mode and the
+// consumption reports, are not mirrored: use the parent
+// package for them.
+//
+// An Iter doesn't panic when New fails, the items are stored
+// in a Slice instead and the error is reported by Err.
+//
+// See the parent package for the detailed semantics of every API.
+package generic
+
+import "fmt"
+
+// Iterable is capable of traversing items of type T from some
+// kind of collection. See the parent package for the protocol.
+type Iterable[T any] interface {
+	// New initializes a new Iterable instance.
+	New() (Iterable[T], error)
+	// Add pushes an item into the existing Iterable.
+	Add(T)
+	// Next emits an item from the existing Iterable.
+	// The bool indicates whether there is any more items.
+	Next() (T, bool)
+}
+
+// Enumerator is capable of traversing items and their indexes.
+type Enumerator[T any] interface {
+	Enumerate() (int, T, bool)
+}
+
+// Rewinder can rewind the traversal back to its initial state.
+type Rewinder interface {
+	Rewind()
+}
+
+// Resetter resets an Iterable to its initial state.
+type Resetter interface {
+	Reset()
+}
+
+// FromIter converts an Iterable of type T back to []T.
+// The Collect API requires an Iterable to be a FromIter.
+type FromIter[T any] interface {
+	To() []T
+}
+
+// Pair holds two values {X, Y}, typically coming from two
+// different Iterables, see Zip.
+type Pair[X, Y any] struct {
+	X X
+	Y Y
+}
+
+// Iter is an Iterator implements common utility functions
+// for an Iterable of type T.
+type Iter[T any] struct {
+	item Iterable[T]
+	size int
+	err  error
+}
+
+// New creates a new Iter.
+func New[T any](some Iterable[T]) *Iter[T] {
+	return &Iter[T]{item: some}
+}
+
+// Iterable returns the underlying Iterable.
+func (it *Iter[T]) Iterable() Iterable[T] {
+	return it.item
+}
+
+// Err returns the error of the first New which failed while creating
+// this Iterator or the ones it's derived from, the items are then stored
+// in a Slice instead.
+func (it *Iter[T]) Err() error {
+	return it.err
+}
+
+// newItem creates a new Iterable by New, or a Slice when New fails,
+// recording the error for Err.
+func (it *Iter[T]) newItem() Iterable[T] {
+	newitem, err := it.item.New()
+	if err != nil {
+		if it.err == nil {
+			it.err = fmt.Errorf("generic: New of %T failed: %w", it.item, err)
+		}
+		return NewSlice[T]()
+	}
+	return newitem
+}
+
+// derive creates a new Iterator of item, keeping the error of it.
+func derive[T, U any](it *Iter[T], item Iterable[U]) *Iter[U] {
+	return &Iter[U]{item: item, err: it.err}
+}
+
+func (it *Iter[T]) rewind() {
+	if ag, ok := it.item.(Rewinder); ok {
+		ag.Rewind()
+	}
+}
+
+// Filter returns a new Iterator contains only the items which
+// the predicate returned true.
+func (it *Iter[T]) Filter(f func(T) bool) *Iter[T] {
+	newitem := it.newItem()
+	for {
+		elm, more := it.item.Next()
+		if !more {
+			break
+		}
+		if f(elm) {
+			newitem.Add(elm)
+		}
+	}
+	return derive[T, T](it, newitem)
+}
+
+// Map applies f against every item and returns a new Iterator
+// contains the results. See the Map function to change the type.
+func (it *Iter[T]) Map(f func(T) T) *Iter[T] {
+	newitem := it.newItem()
+	for {
+		elm, more := it.item.Next()
+		if !more {
+			break
+		}
+		newitem.Add(f(elm))
+	}
+	return derive[T, T](it, newitem)
+}
+
+// Every applies f with a pair of (index, item) for every item and
+// returns a new Iterator contains the results.
+// Every requires the underlying Iterable also is an Enumerator.
+func (it *Iter[T]) Every(f func(int, T) T) *Iter[T] {
+	newitem := it.newItem()
+	for {
+		i, v, more := it.item.(Enumerator[T]).Enumerate()
+		if !more {
+			break
+		}
+		newitem.Add(f(i, v))
+	}
+	return derive[T, T](it, newitem)
+}
+
+// Or replaces every item for which the predicate returns false
+// with this.
+func (it *Iter[T]) Or(f func(T) bool, this T) *Iter[T] {
+	newitem := it.newItem()
+	for {
+		elm, more := it.item.Next()
+		if !more {
+			break
+		}
+		if f(elm) {
+			newitem.Add(elm)
+		} else {
+			newitem.Add(this)
+		}
+	}
+	return derive[T, T](it, newitem)
+}
+
+// Inspect runs f against every item as it passes through and returns
+// a new Iterator contains the items unchanged.
+func (it *Iter[T]) Inspect(f func(T)) *Iter[T] {
+	return it.Map(func(v T) T {
+		f(v)
+		return v
+	})
+}
+
+// Advance moves the Iterable's item position forward by N times.
+// See the parent package for the meaning of the returns.
+func (it *Iter[T]) Advance(n int) (int, bool) {
+	var more bool
+
+	for i := 0; i < n; i++ {
+		_, more = it.item.Next()
+		if !more {
+			break
+		}
+		it.size++
+	}
+
+	idx := it.size - 1
+	if idx <= 0 {
+		idx = 0
+	}
+	return idx, more
+}
+
+// Count returns the size of the Iterable.
+// A Rewinder is rewinded afterwards.
+func (it *Iter[T]) Count() int {
+	defer func() {
+		if ag, ok := it.item.(Rewinder); ok {
+			ag.Rewind()
+			it.size = 0
+		}
+	}()
+
+	var more = true
+	for more {
+		_, more = it.Advance(1)
+	}
+	return it.size
+}
+
+// Nth returns the n'th item (0-based) and whether it exists.
+// A Rewinder is rewinded afterwards.
+func (it *Iter[T]) Nth(n int) (T, bool) {
+	defer it.rewind()
+
+	for i := 0; i < n; i++ {
+		if _, more := it.item.Next(); !more {
+			var zero T
+			return zero, false
+		}
+	}
+	return it.item.Next()
+}
+
+// Each runs f against each item. A Rewinder is rewinded afterwards.
+func (it *Iter[T]) Each(f func(T)) {
+	defer it.rewind()
+
+	for {
+		elm, more := it.item.Next()
+		if !more {
+			return
+		}
+		f(elm)
+	}
+}
+
+// First returns the first item matching the predicate, its index and
+// whether there is a match at all.
+// First requires the underlying Iterable also is an Enumerator.
+func (it *Iter[T]) First(f func(T) bool) (int, T, bool) {
+	for {
+		i, v, more := it.item.(Enumerator[T]).Enumerate()
+		if !more {
+			var zero T
+			return -1, zero, false
+		}
+		if f(v) {
+			return i, v, true
+		}
+	}
+}
+
+// Last returns the last item matching the predicate, its index and
+// whether there is a match at all.
+// Last requires the underlying Iterable also is an Enumerator.
+func (it *Iter[T]) Last(f func(T) bool) (int, T, bool) {
+	var idx = -1
+	var seen T
+	var found bool
+
+	for {
+		i, v, more := it.item.(Enumerator[T]).Enumerate()
+		if !more {
+			break
+		}
+		if f(v) {
+			found = true
+			seen = v
+			idx = i
+		}
+	}
+	return idx, seen, found
+}
+
+// Find returns the first item matching the predicate and whether there
+// is a match at all, stopping there.
+func (it *Iter[T]) Find(f func(T) bool) (T, bool) {
+	_, v, found := it.find(f)
+	return v, found
+}
+
+// PositionOf is like Find but returns the (0-based) position of the
+// matching item among the items read by this call.
+func (it *Iter[T]) PositionOf(f func(T) bool) (int, bool) {
+	i, _, found := it.find(f)
+	return i, found
+}
+
+func (it *Iter[T]) find(f func(T) bool) (int, T, bool) {
+	for i := 0; ; i++ {
+		v, more := it.item.Next()
+		if !more {
+			var zero T
+			return -1, zero, false
+		}
+		if f(v) {
+			return i, v, true
+		}
+	}
+}
+
+// Contains tells whether an item of it equals v, stopping at the first
+// one.
+func Contains[T comparable](it *Iter[T], v T) bool {
+	_, found := it.Find(func(elm T) bool { return elm == v })
+	return found
+}
+
+// Chain combines the Iterable with other into a new Iterator.
+func (it *Iter[T]) Chain(other Iterable[T]) *Iter[T] {
+	newitem := it.newItem()
+	for _, src := range []Iterable[T]{it.item, other} {
+		for {
+			v, more := src.Next()
+			if !more {
+				break
+			}
+			newitem.Add(v)
+		}
+	}
+	return derive[T, T](it, newitem)
+}
+
+// Fold reduces the items into one value, starting from init.
+// See the Fold function to fold into a different type.
+func (it *Iter[T]) Fold(init T, f func(acc T, v T) T) T {
+	return Fold(it, init, f)
+}
+
+// Collect returns the items back as a []T.
+// Collect requires the Iterable implements FromIter, otherwise,
+// this call will panic.
+func (it *Iter[T]) Collect() []T {
+	return it.item.(FromIter[T]).To()
+}
+
+// Map applies f against every item of it and returns a new Iterator
+// of type U, backed by a Slice.
+func Map[T, U any](it *Iter[T], f func(T) U) *Iter[U] {
+	newitem := NewSlice[U]()
+	for {
+		elm, more := it.item.Next()
+		if !more {
+			break
+		}
+		newitem.Add(f(elm))
+	}
+	return derive[T, U](it, newitem)
+}
+
+// Fold reduces the items of it into one value of type A, starting
+// from init. Same as Each, a Rewinder is rewinded afterwards.
+func Fold[T, A any](it *Iter[T], init A, f func(acc A, v T) A) A {
+	acc := init
+	it.Each(func(v T) {
+		acc = f(acc, v)
+	})
+	return acc
+}
+
+// Zip stitches two Iterables into one Iterator of Pair, stopping at
+// the shorter one.
+func Zip[T, U any](it *Iter[T], other Iterable[U]) *Iter[Pair[T, U]] {
+	np := NewSlice[Pair[T, U]]()
+	for {
+		v1, more1 := it.item.Next()
+		v2, more2 := other.Next()
+		if !more1 || !more2 {
+			break
+		}
+		np.Add(Pair[T, U]{v1, v2})
+	}
+	return derive[T, Pair[T, U]](it, np)
+}
+
+// Into converts the items of it into the target Iterable.
+// If target is a Resetter, Reset is called first.
+// Items for which as returns a non-nil error are skipped.
+func Into[T, U any](it *Iter[T], target Iterable[U], as func(T) (U, error)) *Iter[U] {
+	if r, ok := target.(Resetter); ok {
+		r.Reset()
+	}
+	for {
+		elm, more := it.item.Next()
+		if !more {
+			break
+		}
+		if v, err := as(elm); err == nil {
+			target.Add(v)
+		}
+	}
+	return derive[T, U](it, target)
+}
+
+// From converts the items of other into the Iterable of it.
+// If it is a Resetter, it is Reset and filled in place, otherwise
+// a new Iterable is created by New.
+// Items for which as returns a non-nil error are skipped.
+func From[T, U any](it *Iter[T], other Iterable[U], as func(U) (T, error)) *Iter[T] {
+	newit := it
+	if r, ok := it.item.(Resetter); ok {
+		r.Reset()
+	} else {
+		newit = derive[T, T](it, it.newItem())
+	}
+	for {
+		elm, more := other.Next()
+		if !more {
+			break
+		}
+		if v, err := as(elm); err == nil {
+			newit.item.Add(v)
+		}
+	}
+	return newit
+}
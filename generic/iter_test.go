@@ -0,0 +1,181 @@
+package generic
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestAdapters(t *testing.T) {
+	tests := []struct {
+		desc string
+		run  func(it *Iter[string]) *Iter[string]
+		want string
+	}{
+		{"Filter", func(it *Iter[string]) *Iter[string] {
+			return it.Filter(func(v string) bool { return v != "b" })
+		}, "[a c]"},
+		{"Map", func(it *Iter[string]) *Iter[string] {
+			return it.Map(strings.ToUpper)
+		}, "[A B C]"},
+		{"Every", func(it *Iter[string]) *Iter[string] {
+			return it.Every(func(i int, v string) string { return fmt.Sprintf("%d%s", i, v) })
+		}, "[0a 1b 2c]"},
+		{"Or", func(it *Iter[string]) *Iter[string] {
+			return it.Or(func(v string) bool { return v == "a" }, "-")
+		}, "[a - -]"},
+		{"Chain", func(it *Iter[string]) *Iter[string] {
+			return it.Chain(FromSlice([]string{"d"}))
+		}, "[a b c d]"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := tc.run(New[string](FromSlice([]string{"a", "b", "c"}))).Collect()
+			if fmt.Sprint(got) != tc.want {
+				t.Errorf("%s got: %v, want: %s", tc.desc, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTerminals(t *testing.T) {
+	it := New[int](FromSlice([]int{1, 2, 3, 4}))
+
+	if n := it.Count(); n != 4 {
+		t.Errorf("Count got: %d, want: 4", n)
+	}
+	if v, ok := it.Nth(2); v != 3 || !ok {
+		t.Errorf("Nth(2) got: %d, %t, want: 3, true", v, ok)
+	}
+	if _, ok := it.Nth(9); ok {
+		t.Error("Nth(9) is found in 4 items")
+	}
+	if sum := it.Fold(0, func(acc, v int) int { return acc + v }); sum != 10 {
+		t.Errorf("Fold got: %d, want: 10", sum)
+	}
+	if s := Fold(it, "", func(acc string, v int) string { return acc + strconv.Itoa(v) }); s != "1234" {
+		t.Errorf("Fold into string got: %q, want: \"1234\"", s)
+	}
+
+	even := func(v int) bool { return v%2 == 0 }
+	if i, v, ok := it.First(even); i != 1 || v != 2 || !ok {
+		t.Errorf("First got: %d, %d, %t, want: 1, 2, true", i, v, ok)
+	}
+	it.item.(Rewinder).Rewind()
+	if i, v, ok := it.Last(even); i != 3 || v != 4 || !ok {
+		t.Errorf("Last got: %d, %d, %t, want: 3, 4, true", i, v, ok)
+	}
+}
+
+func TestConversions(t *testing.T) {
+	strs := New[string](FromSlice([]string{"1", "x", "3"}))
+	ints := Into[string, int](strs, NewSlice[int](), func(v string) (int, error) { return strconv.Atoi(v) })
+	if got := fmt.Sprint(ints.Collect()); got != "[1 3]" {
+		t.Errorf("Into got: %s, want: [1 3]", got)
+	}
+
+	back := From[string, int](New[string](NewSlice[string]()), FromSlice([]int{7, 8}), func(v int) (string, error) {
+		return strconv.Itoa(v), nil
+	})
+	if got := fmt.Sprint(back.Collect()); got != "[7 8]" {
+		t.Errorf("From got: %s, want: [7 8]", got)
+	}
+
+	lens := Map(New[string](FromSlice([]string{"a", "bcd"})), func(v string) int { return len(v) })
+	if got := fmt.Sprint(lens.Collect()); got != "[1 3]" {
+		t.Errorf("Map got: %s, want: [1 3]", got)
+	}
+
+	pairs := Zip[string, int](New[string](FromSlice([]string{"age", "size"})), FromSlice([]int{10}))
+	if got := pairs.Collect(); len(got) != 1 || got[0].X != "age" || got[0].Y != 10 {
+		t.Errorf("Zip got: %+v, want: [{age 10}]", got)
+	}
+}
+
+func TestTypedAdapters(t *testing.T) {
+	strs := func(s ...string) *Iter[string] { return New[string](FromSlice(s)) }
+	first := func(v string) byte { return v[0] }
+
+	tests := []struct {
+		desc string
+		got  interface{}
+		want string
+	}{
+		{"Dedup", Dedup(strs("a", "a", "b", "a")).Collect(), "[a b a]"},
+		{"Unique", Unique(strs("apple", "banana", "avocado"), first).Collect(), "[apple banana]"},
+		{"Intern", Intern(strs("a", "a")).Collect(), "[a a]"},
+		{"SortByKeys", strs("b1", "a2", "b3", "a4").SortByKeys(
+			SortKey[string]{Compare: By(first), Desc: true},
+			SortKey[string]{Compare: By(func(v string) string { return v[1:] })},
+		).Collect(), "[b1 b3 a2 a4]"},
+		{"Scan", Scan(strs("a", "bb", "ccc"), 0, func(total int, v string) (int, int) {
+			return total + len(v), total + len(v)
+		}).Collect(), "[1 3 6]"},
+		{"Stateful", Stateful(strs("a", "b", "a"),
+			func() map[string]bool { return map[string]bool{} },
+			func(seen map[string]bool, v string) (map[string]bool, int) {
+				seen[v] = true
+				return seen, len(seen)
+			}).Collect(), "[1 2 2]"},
+	}
+
+	for _, tc := range tests {
+		if got := fmt.Sprint(tc.got); got != tc.want {
+			t.Errorf("%s got: %s, want: %s", tc.desc, got, tc.want)
+		}
+	}
+
+	groups := GroupBy(strs("apple", "banana", "avocado"), first).Collect()
+	if len(groups) != 2 || groups[0].X != 'a' || fmt.Sprint(groups[0].Y.Collect()) != "[apple avocado]" {
+		t.Errorf("GroupBy got: %+v, want: {a [apple avocado]} {b [banana]}", groups)
+	}
+
+	var windows []string
+	Windows(strs("a", "b", "c", "d"), 3).Each(func(w *Iter[string]) {
+		windows = append(windows, fmt.Sprint(w.Collect()))
+	})
+	if got := fmt.Sprint(windows); got != "[[a b c] [b c d]]" {
+		t.Errorf("Windows got: %s, want: [[a b c] [b c d]]", got)
+	}
+
+	valid, invalid := Validate(strs("1", "x", "3"), func(v string) error {
+		_, err := strconv.Atoi(v)
+		return err
+	})
+	if got := fmt.Sprint(valid.Collect()); got != "[1 3]" {
+		t.Errorf("Validate got valid: %s, want: [1 3]", got)
+	}
+	if bad := invalid.Collect(); len(bad) != 1 || bad[0].Index != 1 || bad[0].Item != "x" {
+		t.Errorf("Validate got invalid: %+v, want item 1 (x)", bad)
+	}
+
+	if !Contains(strs("a", "b"), "b") {
+		t.Error("Contains got: false, want: true")
+	}
+	if i, found := strs("a", "1").PositionOf(func(v string) bool { return v == "1" }); i != 1 || !found {
+		t.Errorf("PositionOf got: %d, %t, want: 1, true", i, found)
+	}
+}
+
+// unmakeable fails to create a new Iterable.
+type unmakeable[T any] struct {
+	Iterable[T]
+}
+
+func (unmakeable[T]) New() (Iterable[T], error) {
+	return nil, errors.New("no more")
+}
+
+func TestNewError(t *testing.T) {
+	it := New[string](unmakeable[string]{FromSlice([]string{"a", "b"})})
+	newit := it.Filter(func(v string) bool { return v != "a" }).Map(strings.ToUpper)
+	if got := fmt.Sprint(newit.Collect()); got != "[B]" {
+		t.Errorf("Filter of a failing New got: %s, want: [B]", got)
+	}
+	if err := newit.Err(); err == nil || !strings.Contains(err.Error(), "no more") {
+		t.Errorf("Err got: %v, want the error of New", err)
+	}
+}
@@ -0,0 +1,233 @@
+package generic
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Number is the constraint of Go's builtin integer and float types,
+// as well as the types defined on them.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// NormalizeMode selects the scaling applied by Normalize.
+type NormalizeMode int
+
+const (
+	// MinMax rescales every item into the range [0, 1]
+	// using the minimum and maximum of the Iterable.
+	MinMax NormalizeMode = iota
+	// ZScore rescales every item to its distance from the mean
+	// measured in standard deviations.
+	ZScore
+)
+
+// Normalize rescales every item of it according to the given mode and
+// returns a new Iterator of float64, backed by a Slice.
+// Same as the parent package, a Rewinder is scanned first for the
+// statistics of the whole Iterable, whereas the other Iterables are
+// rescaled against the items seen so far.
+//
+// Example:
+//   newit := Normalize(New[int](FromSlice([]int{0, 5, 10})), MinMax)
+//   produces a newit contains []float64{0, 0.5, 1}
+func Normalize[T Number](it *Iter[T], mode NormalizeMode) *Iter[float64] {
+	var st stats
+	rewindable := it.scan(func(v T) {
+		st.add(float64(v))
+	})
+
+	newitem := NewSlice[float64]()
+	for {
+		elm, more := it.item.Next()
+		if !more {
+			break
+		}
+		f := float64(elm)
+		if !rewindable {
+			st.add(f)
+		}
+		newitem.Add(st.scale(mode, f))
+	}
+	return derive[T, float64](it, newitem)
+}
+
+// Bucketize maps every item of it to the label of the bucket it falls
+// into and returns a new Iterator of the labels, backed by a Slice.
+// bounds must be sorted in ascending order and labels must have exactly
+// one more element than bounds, otherwise Bucketize panics. Bucket i
+// covers [bounds[i-1], bounds[i]).
+//
+// Example:
+//   newit := Bucketize(New[int](FromSlice([]int{1, 15, 30})),
+//      []int{10, 20}, []string{"low", "mid", "high"})
+//   produces a newit contains []string{"low", "mid", "high"}
+func Bucketize[T Number, L any](it *Iter[T], bounds []T, labels []L) *Iter[L] {
+	if len(labels) != len(bounds)+1 {
+		panic(fmt.Sprintf("generic: Bucketize needs %d labels for %d bounds, got %d",
+			len(bounds)+1, len(bounds), len(labels)))
+	}
+
+	newitem := NewSlice[L]()
+	for {
+		elm, more := it.item.Next()
+		if !more {
+			break
+		}
+		i := sort.Search(len(bounds), func(i int) bool { return bounds[i] > elm })
+		newitem.Add(labels[i])
+	}
+	return derive[T, L](it, newitem)
+}
+
+// Clamp bounds every item of it into the range [min, max] and returns a
+// new Iterator contains the bounded items. Clamp panics if min is greater
+// than max. See the Clamp method to clamp a field of the items.
+//
+// Example:
+//   newit := Clamp(New[int](FromSlice([]int{-3, 5, 42})), 0, 10)
+//   produces a newit contains []int{0, 5, 10}
+func Clamp[T Number](it *Iter[T], min, max T) *Iter[T] {
+	return it.Clamp(float64(min), float64(max),
+		func(v T) float64 { return float64(v) },
+		func(_ T, f float64) T { return T(f) })
+}
+
+// Clamp bounds the number value extracts from every item into the range
+// [min, max] and returns a new Iterator contains the bounded items. An
+// item out of the range is replaced by whatever rebuild returns given the
+// item and its clamped value. Clamp panics if min is greater than max.
+func (it *Iter[T]) Clamp(min, max float64, value func(T) float64, rebuild func(old T, clamped float64) T) *Iter[T] {
+	if min > max {
+		panic(fmt.Sprintf("generic: Clamp min %v is greater than max %v", min, max))
+	}
+
+	newitem := it.newItem()
+	for {
+		elm, more := it.item.Next()
+		if !more {
+			break
+		}
+		switch f := value(elm); {
+		case f < min:
+			newitem.Add(rebuild(elm, min))
+		case f > max:
+			newitem.Add(rebuild(elm, max))
+		default:
+			newitem.Add(elm)
+		}
+	}
+	return derive[T, T](it, newitem)
+}
+
+// FilterOutliers drops every item whose value is more than k standard
+// deviations away from the mean and returns a new Iterator contains the
+// rest. Same as Normalize, the statistics come from a prior pass over a
+// Rewinder, or from the items seen before the checked one otherwise.
+//
+// Example:
+//   it := New[int](FromSlice([]int{10, 11, 9, 10, 500}))
+//   newit := it.FilterOutliers(1.5, func(v int) float64 { return float64(v) })
+//   produces a newit contains []int{10, 11, 9, 10}
+func (it *Iter[T]) FilterOutliers(k float64, value func(T) float64) *Iter[T] {
+	newitem := it.newItem()
+	it.outliers(k, value, func(v T, outlier bool) {
+		if !outlier {
+			newitem.Add(v)
+		}
+	})
+	return derive[T, T](it, newitem)
+}
+
+// FlagOutliers is like the FilterOutliers method but keeps every item.
+// It returns a new Iterator of Pair where X is the item and Y tells
+// whether the item is an outlier.
+func FlagOutliers[T any](it *Iter[T], k float64, value func(T) float64) *Iter[Pair[T, bool]] {
+	np := NewSlice[Pair[T, bool]]()
+	it.outliers(k, value, func(v T, outlier bool) {
+		np.Add(Pair[T, bool]{v, outlier})
+	})
+	return derive[T, Pair[T, bool]](it, np)
+}
+
+func (it *Iter[T]) outliers(k float64, value func(T) float64, f func(T, bool)) {
+	var st stats
+	rewindable := it.scan(func(v T) {
+		st.add(value(v))
+	})
+
+	for {
+		elm, more := it.item.Next()
+		if !more {
+			break
+		}
+		v := value(elm)
+		sd := st.stddev()
+		f(elm, sd > 0 && math.Abs(v-st.mean) > k*sd)
+		if !rewindable {
+			st.add(v)
+		}
+	}
+}
+
+// scan runs f against every item of a Rewinder, which is rewinded
+// afterwards for the next pass. It reports whether it is a Rewinder.
+func (it *Iter[T]) scan(f func(T)) bool {
+	if _, ok := it.item.(Rewinder); !ok {
+		return false
+	}
+	it.Each(f)
+	return true
+}
+
+// stats keeps the running statistics of a numeric stream, with
+// Welford's algorithm for the variance.
+type stats struct {
+	n    int
+	min  float64
+	max  float64
+	mean float64
+	m2   float64
+}
+
+func (st *stats) add(f float64) {
+	st.n++
+	if st.n == 1 || f < st.min {
+		st.min = f
+	}
+	if st.n == 1 || f > st.max {
+		st.max = f
+	}
+	delta := f - st.mean
+	st.mean += delta / float64(st.n)
+	st.m2 += delta * (f - st.mean)
+}
+
+// stddev returns the population standard deviation.
+func (st *stats) stddev() float64 {
+	if st.n == 0 {
+		return 0
+	}
+	return math.Sqrt(st.m2 / float64(st.n))
+}
+
+func (st *stats) scale(mode NormalizeMode, f float64) float64 {
+	switch mode {
+	case MinMax:
+		if st.max == st.min {
+			return 0
+		}
+		return (f - st.min) / (st.max - st.min)
+	case ZScore:
+		sd := st.stddev()
+		if sd == 0 {
+			return 0
+		}
+		return (f - st.mean) / sd
+	}
+	panic(fmt.Sprintf("generic: unknown NormalizeMode %d", mode))
+}
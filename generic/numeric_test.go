@@ -0,0 +1,43 @@
+package generic
+
+import (
+	"fmt"
+	"testing"
+)
+
+// oneShot hides the optional interfaces of an Iterable, e.g. Rewinder.
+type oneShot[T any] struct {
+	Iterable[T]
+}
+
+func TestNumeric(t *testing.T) {
+	ints := func() *Iter[int] { return New[int](FromSlice([]int{0, 5, 10})) }
+
+	if got := fmt.Sprint(Normalize(ints(), MinMax).Collect()); got != "[0 0.5 1]" {
+		t.Errorf("Normalize got: %s, want: [0 0.5 1]", got)
+	}
+	// A one-shot source is rescaled against the items seen so far.
+	stream := New[int](oneShot[int]{FromSlice([]int{0, 5, 10})})
+	if got := fmt.Sprint(Normalize(stream, MinMax).Collect()); got != "[0 1 1]" {
+		t.Errorf("Normalize of a stream got: %s, want: [0 1 1]", got)
+	}
+
+	labels := Bucketize(New[int](FromSlice([]int{1, 15, 30})), []int{10, 20}, []string{"low", "mid", "high"})
+	if got := fmt.Sprint(labels.Collect()); got != "[low mid high]" {
+		t.Errorf("Bucketize got: %s, want: [low mid high]", got)
+	}
+
+	if got := fmt.Sprint(Clamp(New[int](FromSlice([]int{-3, 5, 42})), 0, 10).Collect()); got != "[0 5 10]" {
+		t.Errorf("Clamp got: %s, want: [0 5 10]", got)
+	}
+
+	value := func(v int) float64 { return float64(v) }
+	outliers := func() *Iter[int] { return New[int](FromSlice([]int{10, 11, 9, 10, 500})) }
+	if got := fmt.Sprint(outliers().FilterOutliers(1.5, value).Collect()); got != "[10 11 9 10]" {
+		t.Errorf("FilterOutliers got: %s, want: [10 11 9 10]", got)
+	}
+	flags := FlagOutliers(outliers(), 1.5, value).Collect()
+	if len(flags) != 5 || flags[3].Y || !flags[4].Y {
+		t.Errorf("FlagOutliers got: %+v, want only 500 flagged", flags)
+	}
+}
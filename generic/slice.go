@@ -0,0 +1,83 @@
+package generic
+
+import "fmt"
+
+// Slice implements Iterable API for []T.
+// Slice itself is not thread-safe.
+type Slice[T any] struct {
+	idx  int
+	data []T
+}
+
+// NewSlice creates a new empty Slice.
+func NewSlice[T any]() *Slice[T] {
+	return &Slice[T]{idx: -1}
+}
+
+// FromSlice creates a new Slice from a []T.
+func FromSlice[T any](s []T) *Slice[T] {
+	return &Slice[T]{idx: -1, data: s}
+}
+
+// New constructs a new empty Slice from itself.
+func (s *Slice[T]) New() (Iterable[T], error) {
+	return NewSlice[T](), nil
+}
+
+// Next returns the next item and whether there is any more to go.
+func (s *Slice[T]) Next() (T, bool) {
+	s.idx++
+	if s.idx < len(s.data) {
+		return s.data[s.idx], true
+	}
+	var zero T
+	return zero, false
+}
+
+// Enumerate returns a pair of {index, item} as well as a bool
+// to indicate whether there is more to go.
+func (s *Slice[T]) Enumerate() (int, T, bool) {
+	s.idx++
+	if s.idx < len(s.data) {
+		return s.idx, s.data[s.idx], true
+	}
+	var zero T
+	return -1, zero, false
+}
+
+// Rewind sets the Slice to its initial traversal state.
+func (s *Slice[T]) Rewind() {
+	s.idx = -1
+}
+
+// Reset sets the Slice to its initial state, dropping the data.
+func (s *Slice[T]) Reset() {
+	s.Rewind()
+	s.data = nil
+}
+
+// Add appends an item.
+func (s *Slice[T]) Add(v T) {
+	s.data = append(s.data, v)
+}
+
+// Len returns the number of items.
+func (s *Slice[T]) Len() int {
+	return len(s.data)
+}
+
+// Seek moves the Slice so that the next call to Next returns
+// the item at index i.
+func (s *Slice[T]) Seek(i int) {
+	s.idx = i - 1
+}
+
+// To returns the underlying []T back.
+func (s *Slice[T]) To() []T {
+	return s.data
+}
+
+// String implements the Stringer interface for Slice.
+func (s *Slice[T]) String() string {
+	return fmt.Sprintf("%+v", s.data)
+}
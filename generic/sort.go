@@ -0,0 +1,89 @@
+package generic
+
+import "sort"
+
+// Ordered is the constraint of the types ordered by <, see By.
+type Ordered interface {
+	Number | ~string
+}
+
+// SortKey describes one key of a multi-key sort, see SortByKeys.
+type SortKey[T any] struct {
+	// Compare returns a negative number, zero or a positive number when
+	// a is less than, equal to or greater than b, see By.
+	Compare func(a, b T) int
+	// Desc sorts the key in descending order.
+	Desc bool
+}
+
+// By returns a Compare func of SortKey ordering the items by key.
+//
+// Example:
+//   SortKey[User]{Compare: By(func(u User) string { return u.Name })}
+func By[T any, K Ordered](key func(T) K) func(a, b T) int {
+	return func(a, b T) int {
+		switch ka, kb := key(a), key(b); {
+		case ka < kb:
+			return -1
+		case ka > kb:
+			return 1
+		}
+		return 0
+	}
+}
+
+// SortBy sorts the items of it by less and returns a new Iterator
+// contains the sorted items. The sort is stable.
+func (it *Iter[T]) SortBy(less func(a, b T) bool) *Iter[T] {
+	items := it.slice()
+	sort.SliceStable(items, func(i, j int) bool {
+		return less(items[i], items[j])
+	})
+	return derive[T, T](it, it.fill(items))
+}
+
+// SortByKeys sorts the items of it and returns a new Iterator contains
+// the sorted items. Two items are ordered by the first key which differs,
+// items equal by all the keys keep their original order.
+//
+// Example:
+//   it := New[string](FromSlice([]string{"b1", "a2", "b3", "a4"}))
+//   newit := it.SortByKeys(
+//      SortKey[string]{Compare: By(func(v string) string { return v[:1] }), Desc: true},
+//      SortKey[string]{Compare: By(func(v string) string { return v[1:] })})
+//   produces a newit contains []string{"b1", "b3", "a2", "a4"}
+func (it *Iter[T]) SortByKeys(keys ...SortKey[T]) *Iter[T] {
+	return it.SortBy(func(a, b T) bool {
+		for _, k := range keys {
+			c := k.Compare(a, b)
+			if k.Desc {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// slice reads the remaining items of it.
+func (it *Iter[T]) slice() []T {
+	var items []T
+	for {
+		elm, more := it.item.Next()
+		if !more {
+			return items
+		}
+		items = append(items, elm)
+	}
+}
+
+// fill creates a new Iterable of the same type with items.
+func (it *Iter[T]) fill(items []T) Iterable[T] {
+	newitem := it.newItem()
+	for _, v := range items {
+		newitem.Add(v)
+	}
+	return newitem
+}
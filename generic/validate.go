@@ -0,0 +1,74 @@
+package generic
+
+import "fmt"
+
+// ValidationError is an item rejected by Validate.
+type ValidationError[T any] struct {
+	// Index is the (0-based) position of the item in the source.
+	Index int
+	// Item is the rejected item.
+	Item T
+	// Err is the error of the first rule rejecting the item.
+	Err error
+}
+
+// Error implements the error interface for ValidationError.
+func (e *ValidationError[T]) Error() string {
+	return fmt.Sprintf("item %d (%+v): %v", e.Index, e.Item, e.Err)
+}
+
+// Unwrap returns the error of the rule rejecting the item.
+func (e *ValidationError[T]) Unwrap() error {
+	return e.Err
+}
+
+// Validate checks every item of it against the rules, in order, and
+// splits them into two new Iterators: the first one contains the items
+// passing all the rules, the second one a *ValidationError for every
+// item rejected by a rule. The rules after the rejecting one are not run
+// for that item.
+func Validate[T any](it *Iter[T], rules ...func(T) error) (*Iter[T], *Iter[*ValidationError[T]]) {
+	valid := it.newItem()
+	invalid := NewSlice[*ValidationError[T]]()
+
+	for i := 0; ; i++ {
+		elm, more := it.item.Next()
+		if !more {
+			break
+		}
+		if err := validate(elm, rules); err != nil {
+			invalid.Add(&ValidationError[T]{Index: i, Item: elm, Err: err})
+		} else {
+			valid.Add(elm)
+		}
+	}
+	return derive[T, T](it, valid), derive[T, *ValidationError[T]](it, invalid)
+}
+
+func validate[T any](v T, rules []func(T) error) error {
+	for _, rule := range rules {
+		if err := rule(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Partition splits the items of it into two new Iterators in one pass:
+// the first one contains the items for which the predicate returns true,
+// the second one the others, both in the original order.
+func (it *Iter[T]) Partition(f func(T) bool) (*Iter[T], *Iter[T]) {
+	matched, rest := it.newItem(), it.newItem()
+	for {
+		elm, more := it.item.Next()
+		if !more {
+			break
+		}
+		if f(elm) {
+			matched.Add(elm)
+		} else {
+			rest.Add(elm)
+		}
+	}
+	return derive[T, T](it, matched), derive[T, T](it, rest)
+}
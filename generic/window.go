@@ -0,0 +1,49 @@
+package generic
+
+import "fmt"
+
+// Windows returns a new Iterator whose items are the overlapping windows
+// of n consecutive items of it, every one being an Iter of these items.
+// An Iterable with less than n items produces no window. Windows panics
+// if n isn't positive. Windows can't be a method, since an Iter[T] method
+// returning an Iter[*Iter[T]] is an instantiation cycle.
+//
+// Example:
+//   newit := Windows(New[string](FromSlice([]string{"a", "b", "c", "d"})), 3)
+//   produces 2 windows: [a b c] and [b c d]
+func Windows[T any](it *Iter[T], n int) *Iter[*Iter[T]] {
+	return WindowsStep(it, n, 1)
+}
+
+// WindowsStep is like Windows but starts a window every step items,
+// so that the windows don't overlap when step is n.
+func WindowsStep[T any](it *Iter[T], n, step int) *Iter[*Iter[T]] {
+	if n <= 0 || step <= 0 {
+		panic(fmt.Sprintf("generic: WindowsStep needs a positive size and step, got %d and %d", n, step))
+	}
+	windows := NewSlice[*Iter[T]]()
+
+	// last holds the last n items read, skip counts the items to read
+	// before the next window starts.
+	var last []T
+	var skip int
+	for {
+		elm, more := it.item.Next()
+		if !more {
+			break
+		}
+		last = append(last, elm)
+		if len(last) > n {
+			last = last[1:]
+		}
+		if skip > 0 {
+			skip--
+		}
+		if len(last) < n || skip > 0 {
+			continue
+		}
+		windows.Add(derive[T, T](it, it.fill(last)))
+		skip = step
+	}
+	return derive[T, *Iter[T]](it, windows)
+}
@@ -1,3 +1,3 @@
 module github.com/i3d/goiter
 
-go 1.16
+go 1.18
@@ -0,0 +1,152 @@
+package iter
+
+// GroupBy groups the items of the Iterable by their key and returns a new
+// Iterator of *Pair, one per key in the order the keys are first seen:
+// X is the key and Y is an *Iter of the items sharing it, in their
+// original order. The keys must be comparable, see map keys.
+//
+// The Iterable of the returned Iterator is a *Pairs, so the keys and the
+// groups can be reached by its Xs and Ys APIs, or by Unzip.
+//
+// Example:
+//   it := New(FromStrings([]string{"apple", "banana", "avocado"}))
+//   groups := it.GroupBy(func(v interface{}) interface{} { return v.(string)[:1] })
+//   produces {a, [apple avocado]} and {b, [banana]}
+func (it *Iter) GroupBy(key func(interface{}) interface{}) *Iter {
+	groups := NewPairs()
+	index := map[interface{}]Iterable{}
+
+	var read int
+	for {
+		elm, more := it.impl.next()
+		if !more {
+			break
+		}
+		read++
+		k := key(elm)
+		group, ok := index[k]
+		if !ok {
+			group = it.impl.newItem()
+			index[k] = group
+			groups.Add(&Pair{k, it.impl.inner(group)})
+		}
+		group.Add(elm)
+	}
+	return newFromImpl(it.impl.derive("GroupBy", groups, read, groups.Len()))
+}
+
+// Pivot turns a long-format Iterable into a wide summary, like a crosstab:
+// the items are grouped by their row key then by their column key, and
+// agg reduces the values of every {row, column} cell into one.
+//
+// The result maps a row key to a map from a column key to the aggregated
+// cell. Cells without any item are absent. The keys must be comparable.
+//
+// Example:
+//   type Sale struct{ Region, Month string; Amount int }
+//   table := it.Pivot(Field("Region"), Field("Month"), Field("Amount"),
+//      func(values *Iter) interface{} {
+//         sum := 0
+//         values.Each(func(v interface{}) { sum += v.(int) })
+//         return sum
+//      })
+//   table["EU"]["Jan"] => the total amount of EU in January
+func (it *Iter) Pivot(rowKey, colKey, value func(interface{}) interface{}, agg func(values *Iter) interface{}) map[interface{}]map[interface{}]interface{} {
+	cells := map[interface{}]map[interface{}]Iterable{}
+	for {
+		elm, more := it.impl.next()
+		if !more {
+			break
+		}
+		r, c := rowKey(elm), colKey(elm)
+		row, ok := cells[r]
+		if !ok {
+			row = map[interface{}]Iterable{}
+			cells[r] = row
+		}
+		cell, ok := row[c]
+		if !ok {
+			cell, _ = newElems()
+			row[c] = cell
+		}
+		cell.Add(value(elm))
+	}
+
+	table := make(map[interface{}]map[interface{}]interface{}, len(cells))
+	for r, row := range cells {
+		table[r] = make(map[interface{}]interface{}, len(row))
+		for c, cell := range row {
+			table[r][c] = agg(New(cell))
+		}
+	}
+	return table
+}
+
+// Stateful carries a state across the items of the Iterable and returns
+// a new Iterator contains whatever step emits for every item.
+//
+// The state is created by init before the first item, then step takes
+// the current state and an item and returns the next state as well as the
+// item to be emitted.
+//
+// Example (a running count of distinct items):
+//   it := New(FromStrings([]string{"a", "b", "a"}))
+//   newit := it.Stateful(
+//      func() interface{} { return map[string]bool{} },
+//      func(state, v interface{}) (interface{}, interface{}) {
+//         seen := state.(map[string]bool)
+//         seen[v.(string)] = true
+//         return seen, len(seen)
+//      })
+//   produces a newit contains []interface{}{1, 2, 2}
+func (it *Iter) Stateful(init func() interface{}, step func(state, v interface{}) (interface{}, interface{})) *Iter {
+	return newFromImpl(it.impl.stateful("Stateful", nil, init, step))
+}
+
+// Scan is like Stateful but starts with the state init, like Rust's scan,
+// e.g. for running totals. For a state mutated in place, such as a map,
+// Stateful creates a fresh one instead.
+//
+// Example:
+//   it := New(FromStrings([]string{"a", "bb", "ccc"}))
+//   newit := it.Scan(0, func(state, v interface{}) (interface{}, interface{}) {
+//      total := state.(int) + len(v.(string))
+//      return total, total
+//   })
+//   produces a newit contains []interface{}{1, 3, 6}
+func (it *Iter) Scan(init interface{}, f func(state, v interface{}) (interface{}, interface{})) *Iter {
+	return newFromImpl(it.impl.stateful("Scan", nil, func() interface{} { return init }, f))
+}
+
+// StatefulBy is like Stateful but keeps a separate state for every key,
+// e.g. per-user counters. init is called the first time a key is seen.
+// A nil key func shares one state among all the items.
+func (it *Iter) StatefulBy(key func(interface{}) interface{}, init func() interface{}, step func(state, v interface{}) (interface{}, interface{})) *Iter {
+	return newFromImpl(it.impl.stateful("Stateful", key, init, step))
+}
+
+func (it *iter) stateful(stage string, key func(interface{}) interface{}, init func() interface{}, step func(state, v interface{}) (interface{}, interface{})) *iter {
+	newitem, _ := newElems()
+	states := map[interface{}]interface{}{}
+
+	var n int
+	for {
+		elm, more := it.next()
+		if !more {
+			break
+		}
+		var k interface{}
+		if key != nil {
+			k = key(elm)
+		}
+		state, ok := states[k]
+		if !ok {
+			state = init()
+		}
+		state, out := step(state, elm)
+		states[k] = state
+		newitem.Add(out)
+		n++
+	}
+	return it.derive(stage, newitem, n, n)
+}
@@ -0,0 +1,98 @@
+package iter
+
+import (
+	"fmt"
+	"testing"
+)
+
+type sale struct {
+	Region string
+	Month  string
+	Amount int
+}
+
+func TestPivot(t *testing.T) {
+	it := New(&elems{idx: -1})
+	for _, s := range []sale{
+		{"EU", "Jan", 10},
+		{"EU", "Jan", 5},
+		{"EU", "Feb", 7},
+		{"US", "Jan", 1},
+	} {
+		it.impl.item.Add(s)
+	}
+
+	sum := func(values *Iter) interface{} {
+		total := 0
+		values.Each(func(v interface{}) { total += v.(int) })
+		return total
+	}
+	table := it.Pivot(Field("Region"), Field("Month"), Field("Amount"), sum)
+
+	if len(table) != 2 || len(table["EU"]) != 2 || len(table["US"]) != 1 {
+		t.Fatalf("Pivot got: %v, want 2 rows with 2 and 1 columns", table)
+	}
+	if table["EU"]["Jan"] != 15 || table["EU"]["Feb"] != 7 || table["US"]["Jan"] != 1 {
+		t.Errorf("Pivot got: %v, want: map[EU:map[Feb:7 Jan:15] US:map[Jan:1]]", table)
+	}
+	if _, ok := table["US"]["Feb"]; ok {
+		t.Error("Pivot got a cell for {US, Feb} which has no items")
+	}
+}
+
+func TestStateful(t *testing.T) {
+	distinct := New(FromStrings([]string{"a", "b", "a", "c"})).Stateful(
+		func() interface{} { return map[string]bool{} },
+		func(state, v interface{}) (interface{}, interface{}) {
+			seen := state.(map[string]bool)
+			seen[v.(string)] = true
+			return seen, len(seen)
+		})
+	if got := fmt.Sprint(distinct.Collect()); got != "[1 2 2 3]" {
+		t.Errorf("Stateful running distinct count got: %s, want: [1 2 2 3]", got)
+	}
+
+	counters := New(FromStrings([]string{"u1", "u2", "u1", "u1"})).StatefulBy(
+		func(v interface{}) interface{} { return v },
+		func() interface{} { return 0 },
+		func(state, v interface{}) (interface{}, interface{}) {
+			n := state.(int) + 1
+			return n, fmt.Sprintf("%s#%d", v, n)
+		})
+	if got := fmt.Sprint(counters.Collect()); got != "[u1#1 u2#1 u1#2 u1#3]" {
+		t.Errorf("StatefulBy per-key counters got: %s, want: [u1#1 u2#1 u1#2 u1#3]", got)
+	}
+}
+
+func TestScan(t *testing.T) {
+	totals := New(FromStrings([]string{"a", "bb", "ccc"})).Scan(0, func(state, v interface{}) (interface{}, interface{}) {
+		total := state.(int) + len(v.(string))
+		return total, fmt.Sprint(v, "=", total)
+	})
+	if got := fmt.Sprint(totals.Collect()); got != "[a=1 bb=3 ccc=6]" {
+		t.Errorf("Scan running total got: %s, want: [a=1 bb=3 ccc=6]", got)
+	}
+	if got := totals.Report().String(); got != "read 3, emitted 3; Scan: 3 -> 3" {
+		t.Errorf("Scan report got: %s", got)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	it := New(FromStrings([]string{"apple", "banana", "avocado", "cherry", "blueberry"}))
+	groups := it.GroupBy(func(v interface{}) interface{} { return v.(string)[:1] })
+
+	var got []string
+	groups.Each(func(v interface{}) {
+		p := v.(*Pair)
+		got = append(got, fmt.Sprint(p.X, " ", p.Y.(*Iter).Collect()))
+	})
+	if want := "[a [apple avocado] b [banana blueberry] c [cherry]]"; fmt.Sprint(got) != want {
+		t.Errorf("GroupBy got: %v, want: %s", got, want)
+	}
+	if keys := groups.Iterable().(*Pairs).Xs(); fmt.Sprint(keys) != "[a b c]" {
+		t.Errorf("GroupBy keys got: %v, want: [a b c]", keys)
+	}
+	if got := groups.Report().String(); got != "read 5, emitted 3; GroupBy: 5 -> 3" {
+		t.Errorf("GroupBy report got: %s", got)
+	}
+}
@@ -0,0 +1,66 @@
+package iter
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+)
+
+// HashEach returns a new Iterator of *Pair, X being an item of the
+// Iterable and Y its digest by a hash created by h, as []byte, e.g. to
+// fingerprint the records of a dataset. A []byte or string item is
+// hashed as is, any other item by its %v format.
+//
+// The Iterable of the returned Iterator is a *Pairs.
+//
+// Example:
+//   New(FromStrings([]string{"a"})).HashEach(sha256.New)
+//   produces {a, sha256("a")}
+func (it *Iter) HashEach(h func() hash.Hash) *Iter {
+	out := NewPairs()
+	var n int
+	for {
+		elm, more := it.impl.next()
+		if !more {
+			break
+		}
+		d := h()
+		d.Write(hashable(elm))
+		out.Add(&Pair{elm, d.Sum(nil)})
+		n++
+	}
+	return newFromImpl(it.impl.derive("HashEach", out, n, n))
+}
+
+// ChecksumAll returns a single digest of every item of the Iterable, in
+// order, by a hash created by h, e.g. to verify the integrity of a
+// processed dataset. The items are hashed as by HashEach, each one
+// prefixed by its length, so that moving bytes from an item to its
+// neighbour changes the digest.
+//
+// Same as Each, the Iterable is rewinded afterwards if it is a Rewinder.
+//
+// Example:
+//   sum := it.ChecksumAll(sha256.New)
+//   fmt.Printf("%x", sum)
+func (it *Iter) ChecksumAll(h func() hash.Hash) []byte {
+	d := h()
+	if !it.impl.guard() {
+		return d.Sum(nil)
+	}
+	var size [binary.MaxVarintLen64]byte
+	it.impl.each(func(v interface{}) {
+		b := hashable(v)
+		d.Write(size[:binary.PutUvarint(size[:], uint64(len(b)))])
+		d.Write(b)
+	})
+	return d.Sum(nil)
+}
+
+// hashable returns the bytes hashed for v.
+func hashable(v interface{}) []byte {
+	if b, err := bytesOf(v); err == nil {
+		return b
+	}
+	return []byte(fmt.Sprint(v))
+}
@@ -0,0 +1,33 @@
+package iter
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+)
+
+func TestHashEach(t *testing.T) {
+	got := New(FromStrings([]string{"a", "b"})).HashEach(sha256.New).Collect().([]*Pair)
+	want := sha256.Sum256([]byte("b"))
+	if len(got) != 2 || got[1].X != "b" || !bytes.Equal(got[1].Y.([]byte), want[:]) {
+		t.Errorf("HashEach got: %v, want {b, %x} second", got, want)
+	}
+}
+
+func TestChecksumAll(t *testing.T) {
+	sum := func(s ...string) string {
+		return fmt.Sprintf("%x", New(FromStrings(s)).ChecksumAll(sha256.New))
+	}
+	if sum("ab", "c") == sum("a", "bc") {
+		t.Error("ChecksumAll got the same digest for [ab c] and [a bc]")
+	}
+	if sum("ab", "c") != sum("ab", "c") {
+		t.Error("ChecksumAll got different digests for the same items")
+	}
+
+	it := New(FromInts([]int{1, 2}))
+	if a, b := it.ChecksumAll(sha256.New), it.ChecksumAll(sha256.New); !bytes.Equal(a, b) {
+		t.Errorf("ChecksumAll doesn't rewind: %x then %x", a, b)
+	}
+}
@@ -0,0 +1,235 @@
+package iter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ServeJSON streams the items of the Iterable to w as a JSON array,
+// flushing the response every flushEvery items (if w is an http.Flusher),
+// so that a web handler can return a large pipeline without buffering it.
+// The response is sent with the chunked transfer encoding unless a
+// Content-Length is set.
+//
+// ServeJSON stops at the first error, e.g. an item which can't be
+// encoded or a client gone, and returns it. As the status and part of
+// the body are sent by then, the client sees a truncated array.
+// A consumed Iterable, see Consumed, is an error as well, returned
+// before anything is sent.
+// If flushEvery is not positive, the response is flushed only at the
+// end. Same as Each, the Iterable is rewinded afterwards if possible.
+//
+// Example:
+//   func handler(w http.ResponseWriter, r *http.Request) {
+//      if err := query(r).ServeJSON(w, 100); err != nil {
+//         log.Print(err)
+//      }
+//   }
+func (it *Iter) ServeJSON(w http.ResponseWriter, flushEvery int) error {
+	return it.impl.serve(w, flushEvery, "application/json", "[", ",", "]")
+}
+
+// ServeNDJSON is like ServeJSON but streams the items as newline
+// delimited JSON, one item per line.
+func (it *Iter) ServeNDJSON(w http.ResponseWriter, flushEvery int) error {
+	return it.impl.serve(w, flushEvery, "application/x-ndjson", "", "", "")
+}
+
+func (it *iter) serve(w http.ResponseWriter, flushEvery int, contentType, open, sep, end string) error {
+	if !it.guard() {
+		return it.err
+	}
+	if err := it.checkConsumed(); err != nil {
+		return err
+	}
+	defer it.rewind()
+
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+
+	if _, err := w.Write([]byte(open)); err != nil {
+		return err
+	}
+	for n := 0; ; n++ {
+		elm, more := it.next()
+		if !more {
+			break
+		}
+		if n > 0 && sep != "" {
+			if _, err := w.Write([]byte(sep)); err != nil {
+				return err
+			}
+		}
+		// Encode terminates every item with a newline, which is
+		// the NDJSON delimiter and a valid JSON whitespace.
+		if err := enc.Encode(elm); err != nil {
+			return err
+		}
+		if flusher != nil && flushEvery > 0 && (n+1)%flushEvery == 0 {
+			flusher.Flush()
+		}
+	}
+	if _, err := w.Write([]byte(end)); err != nil {
+		return err
+	}
+	if flusher != nil {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// PostOptions configures PostJSON.
+type PostOptions struct {
+	// BatchSize is the number of items per request, 100 if zero.
+	BatchSize int
+	// Timeout bounds every request, including reading its response.
+	// Zero means no timeout.
+	Timeout time.Duration
+	// Retries is the number of times a failed request is sent again.
+	// A request fails on a network error, a 5xx or a 429 status. Other
+	// statuses are not retried.
+	Retries int
+	// Backoff is the delay before the first retry, doubled for every
+	// following one, 100ms if zero.
+	Backoff time.Duration
+	// DeadLetter receives the batches which failed every attempt along
+	// with the last error, then the items left are posted. If nil,
+	// PostJSON stops at the first failed batch and returns its error.
+	DeadLetter func(batch []interface{}, err error)
+	// Header is added to every request, e.g. for an Authorization.
+	Header http.Header
+	// Client sends the requests, http.DefaultClient if nil.
+	Client *http.Client
+}
+
+// PostJSON posts the items of the Iterable to url in batches, each one
+// sent as a JSON array, e.g. to forward processed events to a webhook.
+// Failed requests are retried with an exponential backoff, see
+// PostOptions.
+//
+// PostJSON returns the number of items delivered and the error which
+// stopped it, if any, e.g. the error of ctx, or a *MisuseError with
+// ErrConsumed on a consumed Iterable, see Consumed. Same as Each, the
+// Iterable is rewinded afterwards if possible.
+//
+// Example:
+//   n, err := it.PostJSON(ctx, "https://hooks.example.com/events", PostOptions{
+//      BatchSize:  50,
+//      Retries:    3,
+//      DeadLetter: func(batch []interface{}, err error) { spool(batch) },
+//   })
+func (it *Iter) PostJSON(ctx context.Context, url string, opts PostOptions) (int, error) {
+	if !it.impl.guard() {
+		return 0, it.impl.err
+	}
+	if err := it.impl.checkConsumed(); err != nil {
+		return 0, err
+	}
+	defer it.impl.rewind()
+
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = 100 * time.Millisecond
+	}
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+
+	var sent int
+	batch := make([]interface{}, 0, opts.BatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := post(ctx, url, batch, opts)
+		switch {
+		case err == nil:
+			sent += len(batch)
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case opts.DeadLetter != nil:
+			opts.DeadLetter(batch, err)
+		default:
+			return err
+		}
+		// The batch may be kept by the DeadLetter.
+		batch = make([]interface{}, 0, opts.BatchSize)
+		return nil
+	}
+
+	for {
+		elm, more := it.impl.next()
+		if !more {
+			return sent, flush()
+		}
+		batch = append(batch, elm)
+		if len(batch) == opts.BatchSize {
+			if err := flush(); err != nil {
+				return sent, err
+			}
+		}
+	}
+}
+
+// post sends batch to url, retrying the failed requests.
+func post(ctx context.Context, url string, batch []interface{}, opts PostOptions) error {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+
+	backoff := opts.Backoff
+	for attempt := 0; ; attempt++ {
+		var retry bool
+		retry, err = postOnce(ctx, url, body, opts)
+		if err == nil || !retry || attempt == opts.Retries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// postOnce sends one request, the bool tells whether it may be retried.
+func postOnce(ctx context.Context, url string, body []byte, opts PostOptions) (bool, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	for k, vs := range opts.Header {
+		req.Header[k] = vs
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := opts.Client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode/100 == 2 {
+		return false, nil
+	}
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("iter: POST %s: %s", url, resp.Status)
+}
@@ -0,0 +1,87 @@
+package iter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestServeJSON(t *testing.T) {
+	it := New(FromStrings([]string{"a", "b", "c"}))
+
+	rec := httptest.NewRecorder()
+	if err := it.ServeJSON(rec, 2); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil || len(got) != 3 {
+		t.Errorf("ServeJSON body %q decodes into %v with error: %v", rec.Body, got, err)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" || !rec.Flushed {
+		t.Errorf("ServeJSON got Content-Type %q, flushed %v", ct, rec.Flushed)
+	}
+
+	rec = httptest.NewRecorder()
+	if err := it.ServeNDJSON(rec, 0); err != nil || rec.Body.String() != "\"a\"\n\"b\"\n\"c\"\n" {
+		t.Errorf("ServeNDJSON got body %q with error: %v", rec.Body, err)
+	}
+
+	rec = httptest.NewRecorder()
+	if err := New(FromStrings(nil)).ServeJSON(rec, 1); err != nil || rec.Body.String() != "[]" {
+		t.Errorf("ServeJSON of nothing got body %q with error: %v", rec.Body, err)
+	}
+
+	bad := &elems{idx: -1}
+	bad.Add(func() {})
+	if err := New(bad).ServeJSON(httptest.NewRecorder(), 1); err == nil {
+		t.Error("ServeJSON of a func got no error")
+	}
+}
+
+func TestPostJSON(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]string
+	fails := 1
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		var batch []string
+		json.NewDecoder(r.Body).Decode(&batch)
+		switch {
+		case batch[0] == "bad":
+			w.WriteHeader(http.StatusBadRequest)
+		case fails > 0:
+			fails--
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			batches = append(batches, batch)
+		}
+	}))
+	defer srv.Close()
+
+	var dead []interface{}
+	it := New(FromStrings([]string{"a", "b", "bad", "c", "d"}))
+	n, err := it.PostJSON(context.Background(), srv.URL, PostOptions{
+		BatchSize:  2,
+		Retries:    1,
+		Backoff:    time.Millisecond,
+		DeadLetter: func(batch []interface{}, err error) { dead = append(dead, batch...) },
+	})
+	if err != nil || n != 3 {
+		t.Errorf("PostJSON got: %d, %v, want: 3, nil", n, err)
+	}
+	if fmt.Sprint(batches) != "[[a b] [d]]" || fmt.Sprint(dead) != "[bad c]" {
+		t.Errorf("PostJSON posted: %v and dead-lettered: %v, want: [[a b] [d]] and [bad c]", batches, dead)
+	}
+
+	// Without a DeadLetter, the first failed batch stops PostJSON.
+	n, err = it.PostJSON(context.Background(), srv.URL, PostOptions{BatchSize: 2})
+	if err == nil || n != 2 {
+		t.Errorf("PostJSON without DeadLetter got: %d, %v, want: 2 and an error", n, err)
+	}
+}
@@ -2,61 +2,227 @@ package iter
 
 import (
 	"fmt"
-	"sync/atomic"
+	"reflect"
 )
 
 type iter struct {
 	item Iterable
 	size int
+
+	// pos counts the items read since the last rewind,
+	// exhausted tells whether the Iterable has no more items.
+	pos       int
+	exhausted bool
+
+	// The strict mode, see Strict.
+	strict bool
+	err    error
+	// The rewinding of the terminals, see WithRewind.
+	policy RewindPolicy
+
+	// sources are the Iterables this one is built from but the copies
+	// materialized by the stages: the one given to New and the ones
+	// combined by the stages, e.g. Zip, see Iter.Rewind.
+	sources []Iterable
+
+	// The bookkeeping for Report, one per stage, see derive.
+	stats []stageStat
+}
+
+// stageStat tells how many items a stage has read and emitted.
+type stageStat struct {
+	stage   string
+	read    int
+	emitted int
 }
 
 func newIter(item Iterable) *iter {
 	return &iter{item: item}
 }
 
-func (it *iter) filter(f FilterFunc) *iter {
-	newitem, err := it.item.New()
-	if err != nil {
-		panic(err)
+// derive creates the iter produced by the stage named stage out of it,
+// recording how many items the stage has read and emitted.
+//
+// The new iter does not refer to it, so that the copies materialized by
+// the former stages are released as soon as their Iterators are.
+func (it *iter) derive(stage string, item Iterable, read, emitted int, others ...Iterable) *iter {
+	stats := make([]stageStat, len(it.stats), len(it.stats)+1)
+	copy(stats, it.stats)
+	stats = append(stats, stageStat{stage: stage, read: read, emitted: emitted})
+
+	sources := append([]Iterable(nil), it.sources...)
+	if len(it.stats) == 0 {
+		sources = append(sources, it.item)
+	}
+	sources = append(sources, others...)
+	return &iter{item: item, sources: sources, stats: stats,
+		strict: it.strict, err: it.err, policy: it.policy}
+}
+
+// wrap creates the iter of a lazy Iterable reading it, e.g. a Pipeline
+// run. The new iter keeps the settings, the error and the sources of it,
+// but starts a fresh report, see Report.
+func (it *iter) wrap(item Iterable) *iter {
+	return &iter{item: item, sources: append([]Iterable(nil), it.sources...),
+		strict: it.strict, err: it.err, policy: it.policy}
+}
+
+// next reads the next item from the Iterable, keeping track of the
+// position.
+func (it *iter) next() (interface{}, bool) {
+	if it.consumed() {
+		return nil, false
+	}
+	v, more := it.item.Next()
+	if more && it.strict && it.exhausted {
+		it.misuse(ErrNotFused)
+		return nil, false
+	}
+	if more {
+		it.pos++
+	} else {
+		it.exhausted = true
+		it.failOn()
+	}
+	return v, more
+}
+
+// failing is an Iterable which may stop on an error, e.g. an I/O one.
+type failing interface {
+	Err() error
+}
+
+// failOn records the error of the Iterable if it is failing.
+func (it *iter) failOn() {
+	if err := errOf(it.item); err != nil {
+		it.fail(err)
+	}
+}
+
+// errOf returns the first error of the failing Iterables among items, so
+// that an Iterable wrapping others, e.g. the one of Pipeline.Run, reports
+// their errors by its Err method.
+func errOf(items ...Iterable) error {
+	for _, item := range items {
+		if e, ok := item.(failing); ok {
+			if err := e.Err(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// enumerate is like next for an Enumerator.
+func (it *iter) enumerate() (int, interface{}, bool) {
+	if it.consumed() {
+		return -1, nil, false
+	}
+	if _, ok := it.item.(Enumerator); !ok && it.strict {
+		it.misuse(ErrNotEnumerator)
+		return -1, nil, false
+	}
+	i, v, more := it.item.(Enumerator).Enumerate()
+	if more && it.strict && it.exhausted {
+		it.misuse(ErrNotFused)
+		return -1, nil, false
+	}
+	if more {
+		it.pos++
+	} else {
+		it.exhausted = true
+	}
+	return i, v, more
+}
+
+// rewind rewinds the Iterable if it is a Rewinder, unless the
+// RewindPolicy is NeverRewind.
+func (it *iter) rewind() {
+	if it.policy == NeverRewind {
+		return
+	}
+	it.rewindItem()
+}
+
+// rewindItem rewinds the Iterable if it is a Rewinder.
+func (it *iter) rewindItem() {
+	if ag, ok := it.item.(Rewinder); ok {
+		ag.Rewind()
+		it.size = 0
+		it.pos = 0
+		it.exhausted = false
 	}
+}
 
+func (it *iter) filter(f FilterFunc) *iter {
+	newitem := it.newItem()
+
+	var read, emitted int
 	for {
-		elm, more := it.item.Next()
+		elm, more := it.next()
 		if !more {
 			break
 		}
+		read++
 		if f(elm) {
 			newitem.Add(elm)
+			emitted++
 		}
 	}
-	return newIter(newitem)
+	return it.derive("Filter", newitem, read, emitted)
 }
 
 func (it *iter) apply(f MapFunc) *iter {
-	newitem, err := it.item.New()
-	if err != nil {
-		panic(err)
-	}
+	newitem := it.newItem()
 
+	var n int
 	for {
-		elm, more := it.item.Next()
+		elm, more := it.next()
 		if !more {
 			break
 		}
 		newitem.Add(f(elm))
+		n++
+	}
+	return it.derive("Map", newitem, n, n)
+}
+
+func (it *iter) inspect(f EachFunc) *iter {
+	newitem := it.newItem()
+
+	var n int
+	for {
+		elm, more := it.next()
+		if !more {
+			break
+		}
+		f(elm)
+		newitem.Add(elm)
+		n++
 	}
-	return newIter(newitem)
+	return it.derive("Inspect", newitem, n, n)
 }
 
 func (it *iter) each(f EachFunc) {
-	defer func() {
-		if ag, ok := it.item.(Rewinder); ok {
-			ag.Rewind()
+	defer it.rewind()
+
+	for {
+		elm, more := it.next()
+		if !more {
+			return
 		}
-	}()
+		f(elm)
+	}
+}
+
+// scan is like each for the first pass of a stage reading the Iterable
+// twice: the Iterable is rewound whatever the RewindPolicy, as the second
+// pass is not a traversal of the caller.
+func (it *iter) scan(f EachFunc) {
+	defer it.rewindItem()
 
 	for {
-		elm, more := it.item.Next()
+		elm, more := it.next()
 		if !more {
 			return
 		}
@@ -64,30 +230,98 @@ func (it *iter) each(f EachFunc) {
 	}
 }
 
-func (it *iter) every(f EveryFunc) *iter {
-	newitem, err := it.item.New()
+// newItem creates a new Iterable of the same type. If the New API of
+// the Iterable fails, the error is recorded, see Err, and an in-memory
+// Iterable is created instead so that no item is lost.
+func (it *iter) newItem() Iterable {
+	newitem, err := newOf(it.item)
+	if err != nil {
+		it.fail(err)
+	}
+	return newitem
+}
+
+// newOf creates a new Iterable by the New API of item, or an in-memory
+// one along with the error if it fails.
+func newOf(item Iterable) (Iterable, error) {
+	newitem, err := item.New()
 	if err != nil {
-		panic(err)
+		newitem, _ = newElems()
+		return newitem, fmt.Errorf("iter: New of %T failed: %w", item, err)
 	}
+	return newitem, nil
+}
 
+// inner creates an Iterator of the items of a stage nested in its
+// output, e.g. a group of GroupBy, which shares the error and the modes
+// of it.
+func (it *iter) inner(item Iterable) *Iter {
+	return newFromImpl(&iter{item: item, strict: it.strict, err: it.err, policy: it.policy})
+}
+
+// slice reads every item into a []interface{}.
+// Same as each, the Iterable is rewinded afterwards if possible.
+func (it *iter) slice() []interface{} {
+	lower, _ := sizeHintOf(it.item)
+	out := make([]interface{}, 0, lower)
+	it.each(func(v interface{}) {
+		out = append(out, v)
+	})
+	return out
+}
+
+func (it *iter) collectTo(dst interface{}) error {
+	ptr := reflect.ValueOf(dst)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() || ptr.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("iter: collect needs a non-nil pointer to a slice, got %T", dst)
+	}
+	defer it.rewind()
+
+	// Append to a copy so that dst is untouched on errors.
+	slice := ptr.Elem()
+	lower, _ := sizeHintOf(it.item)
+	out := reflect.AppendSlice(reflect.MakeSlice(slice.Type(), 0, slice.Len()+lower), slice)
+	typ := slice.Type().Elem()
+	for i := 0; ; i++ {
+		v, more := it.next()
+		if !more {
+			break
+		}
+		if v == nil {
+			out = reflect.Append(out, reflect.Zero(typ))
+			continue
+		}
+		rv := reflect.ValueOf(v)
+		if !rv.Type().AssignableTo(typ) {
+			return fmt.Errorf("iter: can't collect item %d of type %T into %s", i, v, slice.Type())
+		}
+		out = reflect.Append(out, rv)
+	}
+	slice.Set(out)
+	return nil
+}
+
+func (it *iter) every(f EveryFunc) *iter {
+	newitem := it.newItem()
+
+	var n int
 	for {
-		i, v, more := it.item.(Enumerator).Enumerate()
+		i, v, more := it.enumerate()
 		if !more {
 			break
 		}
 		newitem.Add(f(i, v))
+		n++
 	}
-	return newIter(newitem)
+	return it.derive("Every", newitem, n, n)
 }
 
 func (it *iter) or(f FilterFunc, this interface{}) *iter {
-	newitem, err := it.item.New()
-	if err != nil {
-		panic(err)
-	}
+	newitem := it.newItem()
 
+	var n int
 	for {
-		elm, more := it.item.Next()
+		elm, more := it.next()
 		if !more {
 			break
 		}
@@ -96,8 +330,9 @@ func (it *iter) or(f FilterFunc, this interface{}) *iter {
 		} else {
 			newitem.Add(this)
 		}
+		n++
 	}
-	return newIter(newitem)
+	return it.derive("Or", newitem, n, n)
 }
 
 func (it *iter) into(target Iterable, as ConvertFunc) *iter {
@@ -105,34 +340,34 @@ func (it *iter) into(target Iterable, as ConvertFunc) *iter {
 		resetter.Reset()
 	}
 
+	var read, emitted int
 	for {
-		elm, more := it.item.Next()
+		elm, more := it.next()
 		if !more {
 			break
 		}
+		read++
 		if newelm, err := as(elm); err == nil {
 			target.Add(newelm)
+			emitted++
 		}
 	}
 
-	return newIter(target)
+	return it.derive("Into", target, read, emitted)
 }
 
 func (it *iter) from(other Iterable, as ConvertFunc) *iter {
 	var newitem Iterable
 	var newit *iter
-	var err error
 
 	if r, ok := it.item.(Resetter); ok {
 		r.Reset()
 		newitem = it.item
 		newit = it
 	} else {
-		newitem, err = it.item.New()
-		if err != nil {
-			panic(err)
-		}
+		newitem = it.newItem()
 		newit = newIter(newitem)
+		newit.err = it.err
 	}
 
 	for {
@@ -151,7 +386,7 @@ func (it *iter) advanceBy(n int) (int, bool) {
 	var more bool
 
 	for i := 0; i < n; i++ {
-		_, more = it.item.Next()
+		_, more = it.next()
 		if !more {
 			break
 		}
@@ -166,12 +401,7 @@ func (it *iter) advanceBy(n int) (int, bool) {
 }
 
 func (it *iter) count() int {
-	defer func() {
-		if ag, ok := it.item.(Rewinder); ok {
-			ag.Rewind()
-			it.size = 0
-		}
-	}()
+	defer it.rewind()
 
 	var more = true
 	for more {
@@ -181,13 +411,17 @@ func (it *iter) count() int {
 }
 
 func (it *iter) first(f FilterFunc) (int, interface{}, bool) {
+	if it.policy == AlwaysRewind {
+		defer it.rewind()
+	}
+
 	var i int
 	var v interface{}
 	var more = true
 
 	// NOTE: consider implementing faster search algorithm.
 	for {
-		i, v, more = it.item.(Enumerator).Enumerate()
+		i, v, more = it.enumerate()
 		if !more {
 			break
 		}
@@ -198,14 +432,34 @@ func (it *iter) first(f FilterFunc) (int, interface{}, bool) {
 	return i, v, more
 }
 
+func (it *iter) find(f FilterFunc) (int, interface{}, bool) {
+	if it.policy == AlwaysRewind {
+		defer it.rewind()
+	}
+
+	for i := 0; ; i++ {
+		v, more := it.next()
+		if !more {
+			return -1, nil, false
+		}
+		if f(v) {
+			return i, v, true
+		}
+	}
+}
+
 func (it *iter) last(f FilterFunc) (int, interface{}, bool) {
+	if it.policy == AlwaysRewind {
+		defer it.rewind()
+	}
+
 	var idx int = -1
 	var seen interface{}
 	var found bool
 
 	// NOTE: consider implementing faster search algorithm.
 	for {
-		i, v, more := it.item.(Enumerator).Enumerate()
+		i, v, more := it.enumerate()
 		if !more {
 			break
 		}
@@ -219,17 +473,16 @@ func (it *iter) last(f FilterFunc) (int, interface{}, bool) {
 }
 
 func (it *iter) chain(other Iterable) *iter {
-	newit, err := it.item.New()
-	if err != nil {
-		panic(err)
-	}
+	newit := it.newItem()
 
+	var n int
 	for {
-		v, more := it.item.Next()
+		v, more := it.next()
 		if !more {
 			break
 		}
 		newit.Add(v)
+		n++
 	}
 
 	for {
@@ -238,83 +491,119 @@ func (it *iter) chain(other Iterable) *iter {
 			break
 		}
 		newit.Add(v)
+		n++
 	}
 
-	return newIter(newit)
+	return it.derive("Chain", newit, n, n, other)
+}
+
+func (it *iter) flatten() *iter {
+	newitem, _ := newElems()
+
+	var read, emitted int
+	add := func(v interface{}) {
+		newitem.Add(v)
+		emitted++
+	}
+	for {
+		elm, more := it.next()
+		if !more {
+			break
+		}
+		read++
+
+		switch inner := elm.(type) {
+		case *Iter:
+			elm = inner.impl.item
+		case Iterable:
+		default:
+			rv := reflect.ValueOf(elm)
+			if k := rv.Kind(); k == reflect.Slice || k == reflect.Array {
+				for i := 0; i < rv.Len(); i++ {
+					add(rv.Index(i).Interface())
+				}
+			} else {
+				add(elm)
+			}
+			continue
+		}
+		for {
+			v, more := elm.(Iterable).Next()
+			if !more {
+				break
+			}
+			add(v)
+		}
+	}
+	return it.derive("Flatten", newitem, read, emitted)
 }
 
 func (it *iter) zip(other Iterable) *iter {
-	np, _ := newPairs()
+	np := NewPairs()
 
+	var read, emitted int
 	for {
-		v1, more1 := it.item.Next()
+		v1, more1 := it.next()
 		v2, more2 := other.Next()
+		if more1 {
+			read++
+		}
 		if !more1 || !more2 {
 			break
 		}
 		p := &Pair{v1, v2}
 		np.Add(p)
+		emitted++
 	}
-	return newIter(np)
+	return it.derive("Zip", np, read, emitted, other)
 }
 
-type pairs struct {
-	idx  int
-	data []*Pair
-	size int
-}
+func (it *iter) unzip() (*iter, *iter) {
+	xs, _ := newElems()
+	ys, _ := newElems()
 
-func newPairs() (Iterable, error) {
-	return &pairs{idx: -1}, nil
-}
-
-func (*pairs) New() (Iterable, error) {
-	return newPairs()
-}
-
-func (ps *pairs) Next() (interface{}, bool) {
-	ps.idx++
-	if ps.idx < ps.size {
-		return ps.data[ps.idx], true
+	var n int
+	for {
+		elm, more := it.next()
+		if !more {
+			break
+		}
+		p := elm.(*Pair)
+		xs.Add(p.X)
+		ys.Add(p.Y)
+		n++
 	}
-	return nil, false
+	return it.derive("Unzip", xs, n, n), it.derive("Unzip", ys, n, n)
 }
 
-func (ps *pairs) Rewind() {
-	ps.idx = -1
-}
+// project reads the *Pair items and keeps whatever f returns for them.
+func (it *iter) project(stage string, f func(*Pair) interface{}) *iter {
+	newitem, _ := newElems()
 
-func (ps *pairs) Reset() {
-	ps.Rewind()
-	ps.data = nil
-	ps.size = 0
-}
-
-// Add inserts an string as an interface into the pairs struct.
-func (ps *pairs) Add(obj interface{}) {
-	input := obj.(*Pair)
-	ps.data = append(ps.data, input)
-	ps.size++
-}
-
-// Enumerate returns a pair of {index, string as interface}
-// as well as a bool to indicate whether there ps more to go.
-func (ps *pairs) Enumerate() (int, interface{}, bool) {
-	ps.idx++
-	if ps.idx < ps.size {
-		return ps.idx, ps.data[ps.idx], true
+	var n int
+	for {
+		elm, more := it.next()
+		if !more {
+			break
+		}
+		newitem.Add(f(elm.(*Pair)))
+		n++
 	}
-	return -1, nil, false
+	return it.derive(stage, newitem, n, n)
 }
 
-// To returns the underlying []*Pair back.
-func (ps *pairs) To() interface{} {
-	return ps.data
-}
-
-// String implements the Stringer interface for pairs.
-func (ps *pairs) String() string {
-	return fmt.Sprintf("%+v", ps.data)
+// mapPairs reads the *Pair items and collects whatever f returns for
+// them into a *Pairs.
+func (it *iter) mapPairs(stage string, f func(*Pair) *Pair) *iter {
+	np := NewPairs()
+	for {
+		elm, more := it.next()
+		if !more {
+			break
+		}
+		np.Add(f(elm.(*Pair)))
+	}
+	return it.derive(stage, np, np.Len(), np.Len())
 }
 
 // String provides a stringify impl for Pair.
@@ -322,60 +611,67 @@ func (p *Pair) String() string {
 	return fmt.Sprintf("{%+v, %+v}", p.X, p.Y)
 }
 
-// === internal for testing ===
-
-// An internal Iterable impl for []int,
-// used for Into/From conversion tests.
-type iterInts struct {
-	data []int
-	idx  int64
+// elems is an Iterable of arbitrary items. It hosts the outcome
+// of the APIs which change the item type, e.g. Normalize.
+type elems struct {
+	idx  int
+	data []interface{}
+	size int
 }
 
-func (is *iterInts) New() (Iterable, error) {
-	return &iterInts{idx: -1}, nil
+func newElems() (Iterable, error) {
+	return &elems{idx: -1}, nil
 }
 
-func (is *iterInts) Next() (interface{}, bool) {
-	need := atomic.AddInt64(&is.idx, 1)
-	var more bool = true
+func (*elems) New() (Iterable, error) {
+	return newElems()
+}
 
-	if need > int64(len(is.data)-1) {
-		more = false
+func (es *elems) Next() (interface{}, bool) {
+	es.idx++
+	if es.idx < es.size {
+		return es.data[es.idx], true
 	}
+	return nil, false
+}
 
-	if !more {
-		return nil, more
-	}
-	return is.data[need], more
+func (es *elems) Rewind() {
+	es.idx = -1
 }
 
-func (is *iterInts) Rewind() {
-	atomic.StoreInt64(&is.idx, -1)
+func (es *elems) Reset() {
+	es.Rewind()
+	es.data = nil
+	es.size = 0
 }
 
-func (is *iterInts) Reset() {
-	is.Rewind()
-	is.data = nil
+func (es *elems) Add(obj interface{}) {
+	es.data = append(es.data, obj)
+	es.size++
 }
 
-func (is *iterInts) Add(obj interface{}) {
-	input := obj.(int)
-	is.data = append(is.data, input)
+func (es *elems) Len() int {
+	return es.size
 }
 
-func (is *iterInts) Enumerate() (int, interface{}, bool) {
-	need := atomic.AddInt64(&is.idx, 1)
-	var more bool = true
+func (es *elems) Seek(i int) {
+	es.idx = i - 1
+}
 
-	if need > int64(len(is.data)-1) {
-		more = false
+func (es *elems) Enumerate() (int, interface{}, bool) {
+	es.idx++
+	if es.idx < es.size {
+		return es.idx, es.data[es.idx], true
 	}
-	if !more {
-		return -1, nil, more
-	}
-	return int(need), is.data[need], more
+	return -1, nil, false
+}
+
+// To returns the underlying []interface{} back.
+func (es *elems) To() interface{} {
+	return es.data
 }
 
-func (is *iterInts) String() string {
-	return fmt.Sprintf("%#+v", is.data)
+// String implements the Stringer interface for elems.
+func (es *elems) String() string {
+	return fmt.Sprintf("%+v", es.data)
 }
@@ -0,0 +1,76 @@
+// Package command reads the lines of the standard output of a command,
+// for fileio.FromCommand and the FromCmdLines of the goiter package.
+package command
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os/exec"
+)
+
+// Command is a started command whose output is read line by line.
+type Command struct {
+	ctx  context.Context
+	cmd  *exec.Cmd
+	sc   *bufio.Scanner
+	done chan struct{}
+	eof  bool
+}
+
+// Start starts cmd, which must not have its Stdout set. The command is
+// killed when ctx is done.
+func Start(ctx context.Context, cmd *exec.Cmd) (*Command, error) {
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	c := &Command{ctx: ctx, cmd: cmd, sc: bufio.NewScanner(out), done: make(chan struct{})}
+	go func() {
+		select {
+		case <-ctx.Done():
+			cmd.Process.Kill()
+		case <-c.done:
+		}
+	}()
+	return c, nil
+}
+
+// Read returns the next line of the output, as a string without its line
+// terminator, or io.EOF once the output ends. Close has to be called
+// after an error, io.EOF included.
+func (c *Command) Read() (interface{}, error) {
+	if c.sc.Scan() {
+		return c.sc.Text(), nil
+	}
+	if err := c.sc.Err(); err != nil {
+		return nil, err
+	}
+	c.eof = true
+	return nil, io.EOF
+}
+
+// Close waits for the command, killing it first unless its output has
+// been read until its end, e.g. after a line too long to be read, as
+// nobody reads the pipe it may be blocked on. It returns the exit error
+// of the command, e.g. an *exec.ExitError for a non-zero exit status,
+// the error of ctx if it's done, or nil if the command has been killed
+// by Close.
+func (c *Command) Close() error {
+	if !c.eof {
+		c.cmd.Process.Kill()
+	}
+	close(c.done)
+	err := c.cmd.Wait()
+	if cerr := c.ctx.Err(); cerr != nil {
+		return cerr
+	}
+	if !c.eof {
+		return nil
+	}
+	return err
+}
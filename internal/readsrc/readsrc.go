@@ -0,0 +1,85 @@
+// Package readsrc implements the Iterables reading their items lazily
+// from a resource, such as the file of a fileio.Source or the connection
+// of a netio.Source.
+package readsrc
+
+import (
+	"errors"
+	"io"
+
+	iter "github.com/i3d/goiter"
+)
+
+// Source is an Iterable of the items read from a resource by a function.
+// The resource is closed once read until its end.
+//
+// Next reports no more items on an error of the function, which is then
+// returned by Err. io.EOF ends the Source without any error.
+type Source struct {
+	c    io.Closer
+	read func() (interface{}, error)
+	err  error
+
+	// The package and the resource, e.g. "fileio" and "a file", for the
+	// panic of Add.
+	pkg, resource string
+}
+
+// New creates a Source reading c by read on behalf of the package pkg,
+// resource describes c, e.g. "a file".
+func New(pkg, resource string, c io.Closer, read func() (interface{}, error)) *Source {
+	return &Source{c: c, read: read, pkg: pkg, resource: resource}
+}
+
+// New implements iter.Iterable. It returns an in-memory Iterable, which
+// is a Rewinder and a FromIter whose To returns a []interface{}, so that
+// the APIs producing new Iterators can store their items.
+func (*Source) New() (iter.Iterable, error) {
+	return iter.FromSlice([]interface{}(nil))
+}
+
+// Add implements iter.Iterable. A Source doesn't accept items, Add
+// panics, see New.
+func (src *Source) Add(interface{}) {
+	panic(src.pkg + ": can't Add to a Source reading " + src.resource)
+}
+
+// Next implements iter.Iterable.
+func (src *Source) Next() (interface{}, bool) {
+	if src.c == nil {
+		return nil, false
+	}
+
+	v, err := src.read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+		src.close(err)
+		return nil, false
+	}
+	return v, true
+}
+
+// close closes the resource, keeping the first error.
+func (src *Source) close(err error) {
+	if cerr := src.c.Close(); err == nil {
+		err = cerr
+	}
+	src.c, src.err = nil, err
+}
+
+// Err returns the error which stopped the Source, if any.
+func (src *Source) Err() error {
+	return src.err
+}
+
+// Close closes the resource of a Source which hasn't been read until its
+// end. It's a no-op otherwise.
+func (src *Source) Close() error {
+	if src.c == nil {
+		return nil
+	}
+	src.close(nil)
+	return src.err
+}
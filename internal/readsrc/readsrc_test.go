@@ -0,0 +1,64 @@
+package readsrc
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	iter "github.com/i3d/goiter"
+)
+
+type closeCounter int
+
+func (c *closeCounter) Close() error {
+	*c++
+	return nil
+}
+
+// reader reads items then fails with err.
+func reader(items []string, err error) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		if len(items) == 0 {
+			return nil, err
+		}
+		v := items[0]
+		items = items[1:]
+		return v, nil
+	}
+}
+
+func TestSource(t *testing.T) {
+	var closed closeCounter
+	src := New("test", "a list", &closed, reader([]string{"a", "b", "c"}, io.EOF))
+	notB := iter.New(src).Filter(func(v interface{}) bool { return v != "b" })
+	if n := notB.Count(); n != 2 || src.Err() != nil || closed != 1 {
+		t.Errorf("Source got %d items with error: %v and %d closes, want: 2, nil, 1", n, src.Err(), closed)
+	}
+	if n := notB.Count(); n != 2 {
+		t.Errorf("Count of the items of a Source traversed again got: %d, want: 2", n)
+	}
+	if err := src.Close(); err != nil || closed != 1 {
+		t.Errorf("Close of a Source read until its end got: %v and %d closes, want: nil, 1", err, closed)
+	}
+
+	boom := errors.New("boom")
+	src = New("test", "a list", &closed, reader([]string{"a"}, boom))
+	if n := iter.New(src).Count(); n != 1 || !errors.Is(src.Err(), boom) {
+		t.Errorf("failing Source got %d items with error: %v, want: 1, boom", n, src.Err())
+	}
+
+	src = New("test", "a list", &closed, reader([]string{"a"}, io.EOF))
+	if err := src.Close(); err != nil || closed != 3 {
+		t.Errorf("Close got: %v and %d closes, want: nil, 3", err, closed)
+	}
+	if _, more := src.Next(); more {
+		t.Error("Next after Close got an item")
+	}
+
+	defer func() {
+		if r := recover(); r != "test: can't Add to a Source reading a list" {
+			t.Errorf("Add got panic: %v", r)
+		}
+	}()
+	src.Add("x")
+}
@@ -76,6 +76,29 @@ type Resetter interface {
 	Reset()
 }
 
+// Lener reports the number of items hosted by an Iterable
+// without traversing it.
+type Lener interface {
+	Len() int
+}
+
+// Seeker moves the traversal position of an Iterable so that
+// the next call to Next yields the item at the given (0-based)
+// index. Together with Lener, an Iterable backed by a slice can
+// offer random access, e.g. for BinarySearch.
+type Seeker interface {
+	Seek(int)
+}
+
+// SizeHinter reports the bounds of the number of items an Iterable
+// yields when traversed, without traversing it, e.g. a lazy Iterable
+// whose exact size isn't known until then. See Iter.SizeHint.
+type SizeHinter interface {
+	// SizeHint returns the lower and the upper bounds, a negative
+	// upper bound means unknown.
+	SizeHint() (int, int)
+}
+
 // Intoer converts an Iterator with Iterable type T
 // to another Iterator with Iterable type U.
 // If the target Iterable is a Resetter, an Intoer
@@ -134,6 +157,10 @@ type EachFunc func(interface{})
 // a new (or same) item for that index.
 type EveryFunc func(int, interface{}) interface{}
 
+// Adapter turns an Iterable into another one, typically wrapping it
+// to transform the items lazily. See Pipe.
+type Adapter func(Iterable) Iterable
+
 // Pair is a generic concept to hold two values {T, U}, where
 // {T, U} could either be the same or different types, typically
 // coming from two different Iterables.
@@ -173,6 +200,31 @@ func newFromImpl(impl *iter) *Iter {
 	return &Iter{impl}
 }
 
+// Iterable returns the underlying Iterable, e.g. to reach the APIs of
+// a concrete Iterable such as the *Pairs produced by Zip.
+func (it *Iter) Iterable() Iterable {
+	return it.impl.item
+}
+
+// Position returns the number of items read from the Iterable through
+// this Iterator since it was created or last rewinded, so the calling
+// code can tell where in the stream the processing currently is.
+//
+// Example:
+//   it := New(FromStrings([]string{"a", "b", "c"}))
+//   it.First(func(v interface{}) bool { return v.(string) == "b" })
+//   it.Position() => 2
+func (it *Iter) Position() int {
+	return it.impl.pos
+}
+
+// Exhausted tells whether the Iterable has been traversed until its end
+// through this Iterator since it was created or last rewinded, in other
+// words, whether an Iterable which isn't a Rewinder has been consumed.
+func (it *Iter) Exhausted() bool {
+	return it.impl.exhausted
+}
+
 // Filter applies a given predicate against every element of the Iterable
 // and return a new Iterator that contains only items which the predicate
 // returned true.
@@ -234,6 +286,18 @@ func (it *Iter) Or(f FilterFunc, this interface{}) *Iter {
 	return newFromImpl(it.impl.or(f, this))
 }
 
+// Inspect runs a function against every item of the Iterable and returns
+// a new Iterator contains the items unchanged, e.g. to log them in the
+// middle of a chain while debugging.
+//
+// Example:
+//   New(FromStrings([]string{"a", "b"})).
+//      Inspect(func(v interface{}) { log.Printf("before: %v", v) }).
+//      Map(f)
+func (it *Iter) Inspect(f EachFunc) *Iter {
+	return newFromImpl(it.impl.inspect(f))
+}
+
 // Advance moves the Iterable's item position forward by N times.
 // If the underlying Iterable is index-based, this means the returned
 // int points to index N-1 when N is a valid move.
@@ -264,6 +328,9 @@ func (it *Iter) Advance(n int) (int, bool) {
 //   it.Count() => 2
 //   it.Filter(func(v interface{}) bool {return v.(string) == "a"}).Count() => 1
 func (it *Iter) Count() int {
+	if !it.impl.guard() {
+		return 0
+	}
 	return it.impl.count()
 }
 
@@ -277,14 +344,13 @@ func (it *Iter) Count() int {
 //   it := New(FromStrings([]string{"a", "b"}))
 //   it.Nth(1) => "b" (0-based index)
 func (it *Iter) Nth(n int) interface{} {
-	defer func() {
-		if ag, ok := it.impl.item.(Rewinder); ok {
-			ag.Rewind()
-		}
-	}()
+	if !it.impl.guard() {
+		return nil
+	}
+	defer it.impl.rewind()
 
 	it.impl.advanceBy(n)
-	v, _ := it.impl.item.Next()
+	v, _ := it.impl.next()
 
 	return v
 }
@@ -304,6 +370,9 @@ func (it *Iter) Nth(n int) interface{} {
 //      a
 //      b
 func (it *Iter) Each(f EachFunc) {
+	if !it.impl.guard() {
+		return
+	}
 	it.impl.each(f)
 }
 
@@ -330,6 +399,9 @@ func (it *Iter) Each(f EachFunc) {
 //   })
 // produces i=1, v="1", found=true
 func (it *Iter) First(f FilterFunc) (int, interface{}, bool) {
+	if !it.impl.guard() {
+		return -1, nil, false
+	}
 	return it.impl.first(f)
 }
 
@@ -354,9 +426,65 @@ func (it *Iter) First(f FilterFunc) (int, interface{}, bool) {
 //   })
 // produces i=1, v="1", found=true
 func (it *Iter) Last(f FilterFunc) (int, interface{}, bool) {
+	if !it.impl.guard() {
+		return -1, nil, false
+	}
 	return it.impl.last(f)
 }
 
+// Find returns the first item match the given predicate, stopping right
+// there. Unlike First, Find doesn't require an Enumerator.
+// The bool indicates whether there is a match at all.
+//
+// Same as First, the Iterable is consumed until the matching item and is
+// not rewinded, see RewindPolicy.
+//
+// Example:
+//   it := New(FromStrings([]string{"a", "1"}))
+//   v, found := it.Find(func(v interface{}) bool {
+//       _, err := strconv.Atoi(v.(string))
+//       return err == nil
+//   })
+// produces v="1", found=true
+func (it *Iter) Find(f FilterFunc) (interface{}, bool) {
+	if !it.impl.guard() {
+		return nil, false
+	}
+	_, v, found := it.impl.find(f)
+	return v, found
+}
+
+// PositionOf is like Find but returns the (0-based) position of the
+// matching item among the items read by this call.
+//
+// Example:
+//   it := New(FromStrings([]string{"a", "1"}))
+//   it.PositionOf(func(v interface{}) bool { return v.(string) == "1" }) => 1, true
+func (it *Iter) PositionOf(f FilterFunc) (int, bool) {
+	if !it.impl.guard() {
+		return -1, false
+	}
+	i, _, found := it.impl.find(f)
+	return i, found
+}
+
+// Contains tells whether an item of the Iterable equals v, according to
+// eq, stopping at the first one. A nil eq compares the items with ==.
+//
+// Example:
+//   it := New(FromStrings([]string{"a", "b"}))
+//   it.Contains("b", nil) => true
+func (it *Iter) Contains(v interface{}, eq EqualFunc) bool {
+	if !it.impl.guard() {
+		return false
+	}
+	if eq == nil {
+		eq = func(x, y interface{}) bool { return x == y }
+	}
+	_, _, found := it.impl.find(func(elm interface{}) bool { return eq(elm, v) })
+	return found
+}
+
 // Chain combines two Iterables with the same type T
 // into a new Iterator.
 // Orders are preserved as they are added.
@@ -369,6 +497,43 @@ func (it *Iter) Chain(other Iterable) *Iter {
 	return newFromImpl(it.impl.chain(other))
 }
 
+// Flatten unnests the items of the Iterable by one level and returns a
+// new Iterator contains the inner items, in order.
+//
+// An item which is an Iterable, an *Iter, a slice or an array is replaced
+// by its own items, any other item is kept as it is. Inner Iterables
+// are read through Next, so they are consumed.
+//
+// Example:
+//   src, _ := FromSlice([]interface{}{[]string{"a", "b"}, FromStrings([]string{"c"}), "d"})
+//   newit := New(src).Flatten()
+//   produces a newit contains []interface{}{"a", "b", "c", "d"}
+func (it *Iter) Flatten() *Iter {
+	return newFromImpl(it.impl.flatten())
+}
+
+// Pipe returns a new Iterator over the Iterable produced by the given
+// adapters, applied in order, so that a custom adapter slots into the
+// chain the same way as the built-in ones.
+//
+// Same as Pipeline.Run, the returned Iterator starts a fresh Report. It
+// has the strict mode, the RewindPolicy and the error of this one.
+//
+// Example:
+//   upper := func(src Iterable) Iterable {
+//      return NewPipeline().Map(func(v interface{}) interface{} {
+//         return strings.ToUpper(v.(string))
+//      }).Run(src).Iterable()
+//   }
+//   newit := New(FromStrings([]string{"a", "b"})).Pipe(upper).Filter(f)
+func (it *Iter) Pipe(adapters ...Adapter) *Iter {
+	item := it.impl.item
+	for _, a := range adapters {
+		item = a(item)
+	}
+	return newFromImpl(it.impl.wrap(item))
+}
+
 // Zip stitches two Iterables into one with item type of
 // *Pair{X, Y} where {X,Y} can either be the same type T
 // or different types {T, U}.
@@ -377,14 +542,16 @@ func (it *Iter) Chain(other Iterable) *Iter {
 // typically the Iterator calls the Zip API) and Pair.Y comes
 // from the 'other' Iterable.
 //
+// The outcome Iterable is a *Pairs, so the result can be
+// collected back into two slices by its Xs and Ys APIs, e.g.
+// it.Zip(other).Iterable().(*Pairs).Xs().
+//
 // Zip stops at the first Iterable where no more item can be
 // produced. In other words, the Iterator produced by Zip only
 // contains len(item) where len is the shortest len b/w the
 // two Iterables.
 //
 // Example:
-// (NOTE: in this example, the FromInts does not exist,
-//  but you get the idea)
 //   it := New(FromStrings([]string{"ago"}))
 //   newit := it.Zip(FromInts([]int{10}))
 // produces Pair{X: "age", Y: 10}
@@ -392,18 +559,77 @@ func (it *Iter) Zip(other Iterable) *Iter {
 	return newFromImpl(it.impl.zip(other))
 }
 
+// Unzip is the opposite of Zip, it splits an Iterable of *Pair into two
+// new Iterators: the first one contains the X of every pair and the
+// second one the Y, in order. Unzip panics on an item which isn't a *Pair.
+//
+// Example:
+//   it := New(FromStrings([]string{"a", "b"})).Zip(FromStrings([]string{"1", "2"}))
+//   xs, ys := it.Unzip()
+//   produces xs contains []interface{}{"a", "b"} and ys []interface{}{"1", "2"}
+func (it *Iter) Unzip() (*Iter, *Iter) {
+	xs, ys := it.impl.unzip()
+	return newFromImpl(xs), newFromImpl(ys)
+}
+
+// Keys returns a new Iterator contains the X of every *Pair of the
+// Iterable, e.g. the keys of GroupBy. Keys panics on an item which isn't
+// a *Pair.
+//
+// Example:
+//   it := New(FromStrings([]string{"a", "b"})).Zip(FromStrings([]string{"1", "2"}))
+//   newit := it.Keys()
+//   produces a newit contains []interface{}{"a", "b"}
+func (it *Iter) Keys() *Iter {
+	return newFromImpl(it.impl.project("Keys", func(p *Pair) interface{} { return p.X }))
+}
+
+// Values is like Keys for the Y of every *Pair.
+func (it *Iter) Values() *Iter {
+	return newFromImpl(it.impl.project("Values", func(p *Pair) interface{} { return p.Y }))
+}
+
+// SwapPairs returns a new Iterator of *Pair where the X and the Y of
+// every *Pair of the Iterable are swapped. SwapPairs panics on an item
+// which isn't a *Pair.
+//
+// Example:
+//   it := New(FromStrings([]string{"a"})).Zip(FromStrings([]string{"1"}))
+//   newit := it.SwapPairs()
+//   produces a newit contains {1, a}
+func (it *Iter) SwapPairs() *Iter {
+	return newFromImpl(it.impl.mapPairs("SwapPairs", func(p *Pair) *Pair { return &Pair{p.Y, p.X} }))
+}
+
+// MapKeys returns a new Iterator of *Pair where f is applied against
+// the X of every *Pair of the Iterable. MapKeys panics on an item which
+// isn't a *Pair.
+func (it *Iter) MapKeys(f MapFunc) *Iter {
+	return newFromImpl(it.impl.mapPairs("MapKeys", func(p *Pair) *Pair { return &Pair{f(p.X), p.Y} }))
+}
+
+// MapValues is like MapKeys for the Y of every *Pair.
+//
+// Example:
+//   it := New(FromStrings([]string{"a"})).Zip(FromStrings([]string{"1"}))
+//   newit := it.MapValues(func(v interface{}) interface{} {
+//      n, _ := strconv.Atoi(v.(string))
+//      return n
+//   })
+//   produces a newit contains {a, 1} where 1 is an int
+func (it *Iter) MapValues(f MapFunc) *Iter {
+	return newFromImpl(it.impl.mapPairs("MapValues", func(p *Pair) *Pair { return &Pair{p.X, f(p.Y)} }))
+}
+
 // Into converts self Iterable with underlying type T to another
 // Iterable with underlying type U.
 // If other is a Resetter, then Reset will be called before the
 // conversion, otherwise assume other is clean.
 //
 // Example:
-// (NOTE: in this example, the NewInts does not exist,
-//  but you get the idea)
 //   it := New(FromStrings([]string{"1", "2"}))
-//   it.Into(NewInts(), func(v interface{}) interface{} {
-//           i, _ := strconv.Atoi(v.(string))
-// 					 return i
+//   it.Into(NewIterInts(), func(v interface{}) (interface{}, error) {
+//      return strconv.Atoi(v.(string))
 //   })
 //   should produce a []int{1, 2}
 func (it *Iter) Into(target Iterable, as ConvertFunc) *Iter {
@@ -415,11 +641,9 @@ func (it *Iter) Into(target Iterable, as ConvertFunc) *Iter {
 // assume clean.
 //
 // Example:
-// (NOTE: in this example, the FromInts does not exist,
-//  but you get the idea)
 //   it := New(NewIterStrings())
-//   it.From(FromInts([]int{1, 2}), func(v interface{}) interface{} {
-//      return fmt.Sprintf("%d", v.(int))
+//   it.From(FromInts([]int{1, 2}), func(v interface{}) (interface{}, error) {
+//      return fmt.Sprintf("%d", v.(int)), nil
 //   })
 //   should produce a []string{"1", "2"}
 func (it *Iter) From(other Iterable, as ConvertFunc) *Iter {
@@ -436,7 +660,9 @@ func (it *Iter) From(other Iterable, as ConvertFunc) *Iter {
 // Collect itself does not consume the Iterator, the whatever
 // mutations/transformations done for the Iterator have
 // consumed the Iterator already, Collect nearly just returns
-// the raw result data.
+// the raw result data. If the Iterable reports an error by an Err
+// method, e.g. a Pipeline run failing to create the result, it is
+// recorded, see Err.
 //
 // Example:
 //   out :=
@@ -448,15 +674,86 @@ func (it *Iter) From(other Iterable, as ConvertFunc) *Iter {
 //   out => []string{"A", "B"}
 func (it *Iter) Collect() interface{} {
 	fromit := it.impl.item.(FromIter)
-	return fromit.To()
+	out := fromit.To()
+	it.impl.failOn()
+	return out
+}
+
+// CollectInto appends every item of the Iterable into the slice pointed
+// by dst, which is typically a pointer to a typed slice such as *[]int or
+// *[]MyStruct, so there is no need to type assert the result of Collect.
+//
+// Unlike Collect, CollectInto doesn't require the Iterable implements
+// FromIter. Same as Each, the Iterable is rewinded afterwards if it is
+// a Rewinder.
+//
+// CollectInto panics if dst isn't a pointer to a slice or an item isn't
+// assignable to the slice element type, see CollectTo for an error
+// instead. A nil item appends a zero value.
+//
+// Example:
+//   var out []string
+//   New(FromStrings([]string{"a", "b"})).CollectInto(&out)
+//   out => []string{"a", "b"}
+func (it *Iter) CollectInto(dst interface{}) {
+	if !it.impl.guard() {
+		return
+	}
+	if err := it.impl.collectTo(dst); err != nil {
+		panic(err)
+	}
+}
+
+// CollectTo is like CollectInto but returns an error instead of
+// panicking when dst isn't a pointer to a slice or an item isn't
+// assignable to the slice element type, and when the Iterable is
+// consumed, see Consumed. dst is left untouched when an error is
+// returned.
+//
+// Example:
+//   var out []int
+//   err := New(FromStrings([]string{"a"})).CollectTo(&out)
+//   err => iter: can't collect item 0 of type string into []int
+func (it *Iter) CollectTo(dst interface{}) error {
+	if !it.impl.guard() {
+		return it.impl.err
+	}
+	if err := it.impl.checkConsumed(); err != nil {
+		return err
+	}
+	return it.impl.collectTo(dst)
+}
+
+// CollectMultimap collects an Iterable of *Pair into a map from every X
+// to the Ys paired with it, in order, e.g. the outcome of Zip. Unlike
+// GroupBy, the pairs don't need to be grouped or sorted by key. The keys
+// must be comparable, see map keys.
+//
+// CollectMultimap panics on an item which isn't a *Pair. Same as Each,
+// the Iterable is rewinded afterwards if it is a Rewinder.
+//
+// Example:
+//   it := New(FromStrings([]string{"a", "b", "a"})).Zip(FromStrings([]string{"1", "2", "3"}))
+//   it.CollectMultimap() => map[a:[1 3] b:[2]]
+func (it *Iter) CollectMultimap() map[interface{}][]interface{} {
+	if !it.impl.guard() {
+		return nil
+	}
+	m := map[interface{}][]interface{}{}
+	it.impl.each(func(v interface{}) {
+		p := v.(*Pair)
+		m[p.X] = append(m[p.X], p.Y)
+	})
+	return m
 }
 
 // An Iterable for []string, ready to be consume by an Iterator
 // such as the Iter.
-// This is the only Iterable implementation provided by the API
-// since Go hasn't yet had Generics. It would be tedious if not
-// impossible to implement all []T. So if there is a need for
-// some T, client will have to implement on thir own.
+// Along with IterInts, IterInt64s, IterFloats and IterBools, this is
+// one of the few Iterable implementations provided by the API since
+// Go hasn't yet had Generics. It would be tedious if not impossible
+// to implement all []T. So if there is a need for some other T, client
+// will have to implement on thir own, or use the generic subpackage.
 
 // IterStrings implements Iterable API for []string.
 // IterStrings itself is not thread-safe.
@@ -513,6 +810,17 @@ func (is *IterStrings) Add(obj interface{}) {
 	is.size++
 }
 
+// Len returns the number of strings.
+func (is *IterStrings) Len() int {
+	return is.size
+}
+
+// Seek moves IterStrings so that the next call to Next returns
+// the string at index i.
+func (is *IterStrings) Seek(i int) {
+	is.idx = i - 1
+}
+
 // Enumerate returns a pair of {index, string as interface}
 // as well as a bool to indicate whether there is more to go.
 func (is *IterStrings) Enumerate() (int, interface{}, bool) {
@@ -532,3 +840,114 @@ func (is *IterStrings) To() interface{} {
 func (is *IterStrings) String() string {
 	return fmt.Sprintf("%+v", is.data)
 }
+
+// Pairs implements Iterable API for []*Pair, it is typically
+// the outcome Iterable of the Zip API.
+// Pairs itself is not thread-safe.
+type Pairs struct {
+	idx  int
+	data []*Pair
+	size int
+}
+
+// NewPairs creates a new empty Pairs struct.
+func NewPairs() *Pairs {
+	return &Pairs{idx: -1}
+}
+
+// FromPairs creates a new Pairs from a []*Pair.
+func FromPairs(ps []*Pair) *Pairs {
+	return &Pairs{idx: -1, data: ps, size: len(ps)}
+}
+
+// New constructs a new empty Pairs from itself.
+func (*Pairs) New() (Iterable, error) {
+	return NewPairs(), nil
+}
+
+// Next returns the next *Pair as an interface{}.
+// bool indicate whether there is any more to go. If false,
+// then Pairs is exhausted.
+func (ps *Pairs) Next() (interface{}, bool) {
+	ps.idx++
+	if ps.idx < ps.size {
+		return ps.data[ps.idx], true
+	}
+	return nil, false
+}
+
+// Rewind for Pairs will set the Iterable to its initial
+// traversal state and ready for start from beginning again.
+func (ps *Pairs) Rewind() {
+	ps.idx = -1
+}
+
+// Reset sets this Pairs to it's initial state.
+// Whatever data hosted would be lost after this call.
+func (ps *Pairs) Reset() {
+	ps.Rewind()
+	ps.data = nil
+	ps.size = 0
+}
+
+// Add inserts a *Pair as an interface into the Pairs struct.
+func (ps *Pairs) Add(obj interface{}) {
+	input := obj.(*Pair)
+	ps.data = append(ps.data, input)
+	ps.size++
+}
+
+// Len returns the number of pairs.
+func (ps *Pairs) Len() int {
+	return ps.size
+}
+
+// Seek moves Pairs so that the next call to Next returns
+// the *Pair at index i.
+func (ps *Pairs) Seek(i int) {
+	ps.idx = i - 1
+}
+
+// Enumerate returns a pair of {index, *Pair as interface}
+// as well as a bool to indicate whether there is more to go.
+func (ps *Pairs) Enumerate() (int, interface{}, bool) {
+	ps.idx++
+	if ps.idx < ps.size {
+		return ps.idx, ps.data[ps.idx], true
+	}
+	return -1, nil, false
+}
+
+// To returns the underlying []*Pair back.
+func (ps *Pairs) To() interface{} {
+	return ps.data
+}
+
+// Pairs returns the underlying []*Pair back, same as To
+// without the need of a type assertion.
+func (ps *Pairs) Pairs() []*Pair {
+	return ps.data
+}
+
+// Xs returns the X of every pair, in order.
+func (ps *Pairs) Xs() []interface{} {
+	xs := make([]interface{}, len(ps.data))
+	for i, p := range ps.data {
+		xs[i] = p.X
+	}
+	return xs
+}
+
+// Ys returns the Y of every pair, in order.
+func (ps *Pairs) Ys() []interface{} {
+	ys := make([]interface{}, len(ps.data))
+	for i, p := range ps.data {
+		ys[i] = p.Y
+	}
+	return ys
+}
+
+// String implements the Stringer interface for Pairs.
+func (ps *Pairs) String() string {
+	return fmt.Sprintf("%+v", ps.data)
+}
@@ -43,7 +43,7 @@ func TestIterString(t *testing.T) {
 
 func TestFrom(t *testing.T) {
 	d := []int{1, 2, 3}
-	ints := &iterInts{d, -1}
+	ints := FromInts(d)
 
 	s := New(NewIterStrings())
 	s.From(ints, func(v interface{}) (interface{}, error) {
@@ -55,7 +55,7 @@ func TestFrom(t *testing.T) {
 
 func TestInto(t *testing.T) {
 	s := New(FromStrings([]string{"1", "2", "3"}))
-	ints := &iterInts{nil, -1}
+	ints := NewIterInts()
 	s.Into(ints, func(v interface{}) (interface{}, error) {
 		return strconv.Atoi(v.(string))
 	})
@@ -65,7 +65,7 @@ func TestInto(t *testing.T) {
 	}
 
 	s = New(FromStrings([]string{"1", "ab", "3"}))
-	ints = &iterInts{nil, -1}
+	ints = NewIterInts()
 	s.Into(ints, func(v interface{}) (interface{}, error) {
 		return strconv.Atoi(v.(string))
 	})
@@ -438,7 +438,7 @@ func TestFunctions(t *testing.T) {
 			"Zip-two-types",
 			New(FromStrings([]string{"age"})),
 			func(it *Iter) *Iter {
-				return it.Zip(&iterInts{[]int{10}, -1})
+				return it.Zip(FromInts([]int{10}))
 			},
 			func(src, dst *Iter) error {
 				o := dst.Collect().([]*Pair)
@@ -575,3 +575,262 @@ func TestFirstLast(t *testing.T) {
 		})
 	}
 }
+
+func TestCollectInto(t *testing.T) {
+	var s []string
+	New(FromStrings([]string{"a", "b"})).CollectInto(&s)
+	if len(s) != 2 || s[0] != "a" || s[1] != "b" {
+		t.Errorf("CollectInto *[]string got: %#v, want: []string{\"a\", \"b\"}", s)
+	}
+
+	ints := []int{0}
+	New(FromInts([]int{1, 2})).CollectInto(&ints)
+	if len(ints) != 3 || ints[0] != 0 || ints[2] != 2 {
+		t.Errorf("CollectInto appends into *[]int got: %#v, want: []int{0, 1, 2}", ints)
+	}
+
+	var out []interface{}
+	New(FromStrings([]string{"a"})).Zip(FromInts([]int{1})).CollectInto(&out)
+	if len(out) != 1 || out[0].(*Pair).Y != 1 {
+		t.Errorf("CollectInto *[]interface{} got: %#v", out)
+	}
+
+	for _, dst := range []interface{}{nil, s, &ints} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("CollectInto(%T) of []string doesn't panic", dst)
+				}
+			}()
+			New(FromStrings([]string{"a"})).CollectInto(dst)
+		}()
+	}
+}
+
+func TestPairs(t *testing.T) {
+	zipped := New(FromStrings([]string{"a", "b", "c"})).Zip(FromInts([]int{1, 2}))
+	ps, ok := zipped.Iterable().(*Pairs)
+	if !ok {
+		t.Fatalf("Zip produced an Iterable of %T, want *Pairs", zipped.Iterable())
+	}
+	if xs, ys := fmt.Sprint(ps.Xs()), fmt.Sprint(ps.Ys()); xs != "[a b]" || ys != "[1 2]" {
+		t.Errorf("Pairs Xs and Ys got: %s and %s, want: [a b] and [1 2]", xs, ys)
+	}
+
+	var seen []string
+	New(FromPairs(ps.Pairs())).Each(func(v interface{}) {
+		seen = append(seen, v.(*Pair).String())
+	})
+	if fmt.Sprint(seen) != "[{a, 1} {b, 2}]" {
+		t.Errorf("FromPairs iterates: %v, want: [{a, 1} {b, 2}]", seen)
+	}
+}
+
+func TestUnzip(t *testing.T) {
+	zipped := New(FromStrings([]string{"a", "b", "c"})).Zip(FromInts([]int{1, 2}))
+	xs, ys := zipped.Unzip()
+	if got := fmt.Sprint(xs.Collect(), ys.Collect()); got != "[a b] [1 2]" {
+		t.Errorf("Unzip got: %s, want: [a b] [1 2]", got)
+	}
+	if got := ys.Report().String(); got != "read 3, emitted 2; Zip: 3 -> 2; Unzip: 2 -> 2" {
+		t.Errorf("Unzip report got: %s", got)
+	}
+}
+
+func TestKeysValues(t *testing.T) {
+	groups := New(FromStrings([]string{"ab", "c", "ad"})).GroupBy(func(v interface{}) interface{} {
+		return v.(string)[:1]
+	})
+	if got := groups.Keys().Collect(); fmt.Sprint(got) != "[a c]" {
+		t.Errorf("Keys got: %v, want: [a c]", got)
+	}
+	groups.Rewind()
+	var sizes []int
+	groups.Values().Each(func(v interface{}) {
+		sizes = append(sizes, v.(*Iter).Count())
+	})
+	if fmt.Sprint(sizes) != "[2 1]" {
+		t.Errorf("Values got groups of sizes: %v, want: [2 1]", sizes)
+	}
+}
+
+func TestCollectTo(t *testing.T) {
+	ints := []int{7}
+	mixed := &elems{idx: -1}
+	for _, v := range []interface{}{1, 2, "x"} {
+		mixed.Add(v)
+	}
+	err := New(mixed).CollectTo(&ints)
+	if err == nil || err.Error() != "iter: can't collect item 2 of type string into []int" {
+		t.Errorf("CollectTo with a mismatched item got error: %v", err)
+	}
+	if len(ints) != 1 || ints[0] != 7 {
+		t.Errorf("CollectTo with an error changed dst into: %v", ints)
+	}
+
+	if err := New(FromStrings(nil)).CollectTo(ints); err == nil {
+		t.Error("CollectTo into a non-pointer got no error")
+	}
+
+	var s []string
+	if err := New(FromStrings([]string{"a"})).CollectTo(&s); err != nil || len(s) != 1 {
+		t.Errorf("CollectTo got: %v with error: %v, want: [a]", s, err)
+	}
+}
+
+func TestPositionExhausted(t *testing.T) {
+	it := New(FromStrings([]string{"a", "b", "c"}))
+	it.First(func(v interface{}) bool { return v.(string) == "b" })
+	if it.Position() != 2 || it.Exhausted() {
+		t.Errorf("after First got position %d exhausted %v, want: 2 false", it.Position(), it.Exhausted())
+	}
+
+	it.Each(func(interface{}) {})
+	if it.Position() != 0 || it.Exhausted() {
+		t.Errorf("after Each got position %d exhausted %v, want: 0 false", it.Position(), it.Exhausted())
+	}
+
+	if v := it.Nth(1); v != "b" {
+		t.Errorf("Nth(1) got: %v, want: b", v)
+	}
+	if n := it.Count(); n != 3 {
+		t.Errorf("Count after Nth got: %d, want: 3", n)
+	}
+
+	once := New(oneShot{FromStrings([]string{"a", "b"})})
+	once.Each(func(interface{}) {})
+	if once.Position() != 2 || !once.Exhausted() {
+		t.Errorf("one-shot got position %d exhausted %v, want: 2 true", once.Position(), once.Exhausted())
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	nested := &elems{idx: -1}
+	for _, v := range []interface{}{
+		[]string{"a", "b"},
+		FromStrings([]string{"c"}),
+		New(FromStrings([]string{"d"})),
+		[1]int{5},
+		"e",
+		[]int{},
+	} {
+		nested.Add(v)
+	}
+	got := New(nested).Flatten().Collect()
+	if fmt.Sprint(got) != "[a b c d 5 e]" {
+		t.Errorf("Flatten got: %v, want: [a b c d 5 e]", got)
+	}
+}
+
+func TestPipe(t *testing.T) {
+	upper := func(src Iterable) Iterable {
+		return NewPipeline().Map(func(v interface{}) interface{} {
+			return strings.ToUpper(v.(string))
+		}).Run(src).Iterable()
+	}
+	reverse := func(src Iterable) Iterable {
+		out := &elems{idx: -1}
+		items := New(src).impl.slice()
+		for i := len(items) - 1; i >= 0; i-- {
+			out.Add(items[i])
+		}
+		return out
+	}
+
+	got := New(FromStrings([]string{"a", "b", "c"})).
+		Filter(func(v interface{}) bool { return v.(string) != "b" }).
+		Pipe(upper, reverse).
+		Map(func(v interface{}) interface{} { return v.(string) + "!" }).
+		Collect()
+	if fmt.Sprint(got) != "[C! A!]" {
+		t.Errorf("Pipe got: %v, want: [C! A!]", got)
+	}
+
+	// The settings and the error of the Iterator are kept.
+	failed := New(unmakeable{FromStrings([]string{"a"})}).WithRewind(NeverRewind).
+		Filter(func(interface{}) bool { return true })
+	piped := failed.Pipe(upper)
+	if piped.impl.policy != NeverRewind || piped.Err() == nil || piped.Err() != failed.Err() {
+		t.Errorf("Pipe got policy: %d, Err: %v, want: %d, %v", piped.impl.policy, piped.Err(), NeverRewind, failed.Err())
+	}
+}
+
+func TestInspect(t *testing.T) {
+	var seen []interface{}
+	got := New(FromStrings([]string{"a", "b"})).
+		Inspect(func(v interface{}) { seen = append(seen, v) }).
+		Map(func(v interface{}) interface{} { return strings.ToUpper(v.(string)) }).
+		Collect()
+	if fmt.Sprint(seen, got) != "[a b] [A B]" {
+		t.Errorf("Inspect saw: %v and passed: %v, want: [a b] and [A B]", seen, got)
+	}
+}
+
+func TestMapPairs(t *testing.T) {
+	zip := func() *Iter {
+		return New(FromStrings([]string{"a", "b"})).Zip(FromStrings([]string{"1", "2"}))
+	}
+	upper := func(v interface{}) interface{} { return strings.ToUpper(v.(string)) }
+	atoi := func(v interface{}) interface{} {
+		n, _ := strconv.Atoi(v.(string))
+		return n * 10
+	}
+
+	tests := []struct {
+		desc string
+		it   *Iter
+		want string
+	}{
+		{"swap", zip().SwapPairs(), "[{1, a} {2, b}]"},
+		{"keys", zip().MapKeys(upper), "[{A, 1} {B, 2}]"},
+		{"values", zip().MapValues(atoi).SwapPairs(), "[{10, a} {20, b}]"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := fmt.Sprint(tc.it.Collect()); got != tc.want {
+				t.Errorf("%s got: %s, want: %s", tc.desc, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	isNum := func(v interface{}) bool {
+		_, err := strconv.Atoi(v.(string))
+		return err == nil
+	}
+	in := []string{"a", "1", "b", "2"}
+
+	once := New(oneShot{FromStrings(in)})
+	if v, found := once.Find(isNum); v != "1" || !found {
+		t.Errorf("Find got: %v, %v, want: 1, true", v, found)
+	}
+	if once.Position() != 2 {
+		t.Errorf("Find read %d items, want: 2", once.Position())
+	}
+	if i, found := once.PositionOf(isNum); i != 1 || !found {
+		t.Errorf("PositionOf on the rest got: %d, %v, want: 1, true", i, found)
+	}
+	if i, found := once.PositionOf(isNum); i != -1 || found {
+		t.Errorf("PositionOf without a match got: %d, %v, want: -1, false", i, found)
+	}
+
+	it := New(FromStrings(in))
+	if !it.Contains("b", nil) || it.Contains("c", nil) {
+		t.Error("Contains with == got wrong results")
+	}
+	it.Rewind()
+	if !it.Contains("B", func(x, y interface{}) bool { return strings.EqualFold(x.(string), y.(string)) }) {
+		t.Error("Contains with EqualFold didn't find B")
+	}
+}
+
+func TestCollectMultimap(t *testing.T) {
+	it := New(FromStrings([]string{"a", "b", "a"})).Zip(FromStrings([]string{"1", "2", "3"}))
+	if got := fmt.Sprint(it.CollectMultimap()); got != "map[a:[1 3] b:[2]]" {
+		t.Errorf("CollectMultimap got: %s, want: map[a:[1 3] b:[2]]", got)
+	}
+	if got := fmt.Sprint(it.SwapPairs().CollectMultimap()); got != "map[1:[a] 2:[b] 3:[a]]" {
+		t.Errorf("CollectMultimap after a rewind got: %s", got)
+	}
+}
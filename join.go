@@ -0,0 +1,188 @@
+package iter
+
+// MergeJoin joins two Iterators which are both sorted by their keys in
+// ascending order, and returns a new Iterator of project(l, r) for every
+// pair of items {l, r} whose keys are equal, in other words, an inner join.
+//
+// cmpKeys compares the key of a left item with the key of a right item and
+// returns a negative number, zero or a positive number when the left key
+// is less than, equal to or greater than the right key.
+//
+// MergeJoin is lazy and reads both sides in a single pass. Only the right
+// items sharing the key of the current left item are kept in memory, so
+// the memory usage is O(1) when the right keys are unique.
+//
+// The returned Iterable can only be traversed once and doesn't accept Add.
+//
+// Example:
+//   left := New(FromStrings([]string{"a1", "b1", "c1"}))
+//   right := New(FromStrings([]string{"a2", "c2", "d2"}))
+//   MergeJoin(left, right,
+//      func(l, r interface{}) int {
+//         return strings.Compare(l.(string)[:1], r.(string)[:1])
+//      },
+//      func(l, r interface{}) interface{} { return l.(string) + r.(string) })
+//   produces "a1a2", "c1c2"
+func MergeJoin(left, right *Iter, cmpKeys func(l, r interface{}) int, project func(l, r interface{}) interface{}) *Iter {
+	return New(&mergeJoin{
+		left:    left.impl.item,
+		right:   right.impl.item,
+		cmp:     cmpKeys,
+		project: project,
+	})
+}
+
+// mergeJoin is the lazy Iterable behind MergeJoin.
+type mergeJoin struct {
+	left    Iterable
+	right   Iterable
+	cmp     func(l, r interface{}) int
+	project func(l, r interface{}) interface{}
+
+	started bool
+	l       interface{}
+	// group holds the right items sharing the key of l,
+	// gi points to the next one to be joined with l.
+	group []interface{}
+	gi    int
+	// r is the right item following the group.
+	r     interface{}
+	rmore bool
+}
+
+// Err returns the error of either side, if any.
+func (j *mergeJoin) Err() error {
+	return errOf(j.left, j.right)
+}
+
+func (*mergeJoin) New() (Iterable, error) {
+	return newElems()
+}
+
+func (*mergeJoin) Add(interface{}) {
+	panic("iter: Add is not supported by a merge joined Iterable")
+}
+
+func (mj *mergeJoin) Next() (interface{}, bool) {
+	if !mj.started {
+		mj.started = true
+		mj.r, mj.rmore = mj.right.Next()
+	}
+
+	for {
+		if mj.gi < len(mj.group) {
+			r := mj.group[mj.gi]
+			mj.gi++
+			return mj.project(mj.l, r), true
+		}
+
+		l, more := mj.left.Next()
+		if !more {
+			return nil, false
+		}
+		mj.l, mj.gi = l, 0
+		// A duplicated left key joins the same group again.
+		if len(mj.group) > 0 && mj.cmp(l, mj.group[0]) == 0 {
+			continue
+		}
+
+		mj.group = mj.group[:0]
+		for mj.rmore && mj.cmp(l, mj.r) > 0 {
+			mj.r, mj.rmore = mj.right.Next()
+		}
+		if !mj.rmore {
+			// Nothing left on the right side to join with.
+			return nil, false
+		}
+		for mj.rmore && mj.cmp(l, mj.r) == 0 {
+			mj.group = append(mj.group, mj.r)
+			mj.r, mj.rmore = mj.right.Next()
+		}
+	}
+}
+
+// JoinOptions configures Pipeline.Join.
+type JoinOptions struct {
+	// LeftKey and RightKey return the join key of an item of the
+	// Pipeline and of the right Iterable.
+	LeftKey, RightKey func(interface{}) interface{}
+	// Project returns the item emitted for a pair of items whose keys
+	// are equal.
+	Project func(l, r interface{}) interface{}
+	// Sorted hints that both sides are sorted by their keys in
+	// ascending order, the keys being strings, numbers or time.Time.
+	Sorted bool
+}
+
+// Join returns a new Pipeline with an inner join stage: every item is
+// joined with the items of right sharing its key. The algorithm is chosen
+// from the hints, as shown by Explain:
+//   - a "MergeJoin" when the sides are Sorted, which streams right in a
+//     single pass, holding only the right items sharing the current key,
+//   - a "HashJoin" otherwise, which reads right into a hash table on the
+//     first item of every run. The keys must be comparable.
+// right is rewound on every run if it is a Rewinder.
+//
+// Example:
+//   p := NewPipeline().Join(users, JoinOptions{
+//      LeftKey:  Field("UserID"),
+//      RightKey: Field("ID"),
+//      Project:  func(l, r interface{}) interface{} { return &Pair{l, r} },
+//   })
+//   p.Explain() => 1. HashJoin
+func (p *Pipeline) Join(right Iterable, opts JoinOptions) *Pipeline {
+	rewind := func() {
+		if r, ok := right.(Rewinder); ok {
+			r.Rewind()
+		}
+	}
+	if opts.Sorted {
+		return p.then("MergeJoin", sizeUnknown, func(Params) step {
+			var started, rmore, grouped bool
+			var r, key interface{}
+			var group []interface{}
+			return func(v interface{}, emit func(interface{})) {
+				k := opts.LeftKey(v)
+				if !grouped || compare(key, k) != 0 {
+					if !started {
+						started = true
+						rewind()
+						r, rmore = right.Next()
+					}
+					for rmore && compare(opts.RightKey(r), k) < 0 {
+						r, rmore = right.Next()
+					}
+					group = group[:0]
+					for rmore && compare(opts.RightKey(r), k) == 0 {
+						group = append(group, r)
+						r, rmore = right.Next()
+					}
+					key, grouped = k, true
+				}
+				for _, r := range group {
+					emit(opts.Project(v, r))
+				}
+			}
+		})
+	}
+	return p.then("HashJoin", sizeUnknown, func(Params) step {
+		var table map[interface{}][]interface{}
+		return func(v interface{}, emit func(interface{})) {
+			if table == nil {
+				table = map[interface{}][]interface{}{}
+				rewind()
+				for {
+					r, more := right.Next()
+					if !more {
+						break
+					}
+					k := opts.RightKey(r)
+					table[k] = append(table[k], r)
+				}
+			}
+			for _, r := range table[opts.LeftKey(v)] {
+				emit(opts.Project(v, r))
+			}
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package iter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMergeJoin(t *testing.T) {
+	byPrefix := func(l, r interface{}) int {
+		return strings.Compare(l.(string)[:1], r.(string)[:1])
+	}
+	concat := func(l, r interface{}) interface{} { return l.(string) + r.(string) }
+
+	tests := []struct {
+		desc        string
+		left, right []string
+		want        string
+	}{
+		{"unique", []string{"a1", "b1", "c1"}, []string{"a2", "c2", "d2"}, "[a1a2 c1c2]"},
+		{"left-dups", []string{"a1", "a3", "b1"}, []string{"a2", "b2"}, "[a1a2 a3a2 b1b2]"},
+		{"right-dups", []string{"a1", "b1"}, []string{"a2", "a3", "c2"}, "[a1a2 a1a3]"},
+		{"many-to-many", []string{"a1", "a3"}, []string{"a2", "a4"}, "[a1a2 a1a4 a3a2 a3a4]"},
+		{"no-match", []string{"a1", "b1"}, []string{"c2"}, "[]"},
+		{"empty-right", []string{"a1"}, nil, "[]"},
+		{"empty-left", nil, []string{"a1"}, "[]"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			joined := MergeJoin(New(FromStrings(tc.left)), New(FromStrings(tc.right)), byPrefix, concat)
+			if got := fmt.Sprint(joined.impl.slice()); got != tc.want {
+				t.Errorf("MergeJoin(%v, %v) got: %s, want: %s", tc.left, tc.right, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMergeBy(t *testing.T) {
+	byValue := func(a, b interface{}) int { return strings.Compare(a.(string), b.(string)) }
+	byLen := func(a, b interface{}) int { return len(a.(string)) - len(b.(string)) }
+
+	tests := []struct {
+		desc    string
+		cmp     func(a, b interface{}) int
+		sources [][]string
+		want    string
+	}{
+		{"sorted", byValue, [][]string{{"a", "d"}, {"b", "c"}, {"e"}}, "[a b c d e]"},
+		{"unsorted", byValue, [][]string{{"c", "a"}, {"b"}}, "[b c a]"},
+		{"ties", byLen, [][]string{{"x1", "z1"}, {"y1"}}, "[x1 z1 y1]"},
+		{"empty", byValue, [][]string{{}, {"a"}, nil}, "[a]"},
+		{"none", byValue, nil, "[]"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var sources []Iterable
+			for _, s := range tc.sources {
+				sources = append(sources, FromStrings(s))
+			}
+			if got := fmt.Sprint(MergeBy(tc.cmp, sources...).impl.slice()); got != tc.want {
+				t.Errorf("MergeBy(%v) got: %s, want: %s", tc.sources, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// Package kv loads the items of an Iterator of the
+// github.com/i3d/goiter package from key-value stores and persists
+// them into key-value stores.
+//
+// The package doesn't link any store. Embedded stores such as bbolt or
+// badger are plugged in by a few lines of shim implementing Store, which
+// keeps goiter free of external dependencies.
+package kv
+
+import (
+	"bytes"
+	"fmt"
+
+	iter "github.com/i3d/goiter"
+)
+
+// Store is a key-value store.
+type Store interface {
+	// Get returns the value of key, the bool tells whether key exists.
+	Get(key []byte) ([]byte, bool, error)
+	// Set stores value under key.
+	Set(key, value []byte) error
+	// Scan returns a Cursor over the keys greater than or equal to
+	// from, in ascending order.
+	Scan(from []byte) Cursor
+}
+
+// Cursor traverses the entries of a Store.
+type Cursor interface {
+	// Next returns the next entry, the bool tells whether there is one.
+	Next() (key, value []byte, ok bool)
+	// Err returns the error which stopped the Cursor, if any.
+	Err() error
+	// Close releases the Cursor, e.g. its read transaction.
+	Close() error
+}
+
+// Codec converts between the items and the entries of a Store.
+type Codec struct {
+	// Key returns the key of an item, e.g. its ID.
+	Key func(interface{}) []byte
+	// Encode returns the value stored for an item.
+	Encode func(interface{}) ([]byte, error)
+	// Decode returns the item of an entry.
+	Decode func(key, value []byte) (interface{}, error)
+}
+
+// Save persists every item of it into s and returns the number of items
+// written. If resume is true, the items whose key is already in s are
+// skipped, so a large pipeline interrupted midway can be run again
+// without redoing the items it has saved.
+//
+// Save stops at the first error of s or of the Codec.
+//
+// Example:
+//   n, err := kv.Save(store, it, codec, true)
+func Save(s Store, it *iter.Iter, c Codec, resume bool) (int, error) {
+	var n int
+	var err error
+	it.Each(func(v interface{}) {
+		if err != nil {
+			return
+		}
+		key := c.Key(v)
+		if resume {
+			var found bool
+			if _, found, err = s.Get(key); err != nil || found {
+				return
+			}
+		}
+		var value []byte
+		if value, err = c.Encode(v); err != nil {
+			err = fmt.Errorf("kv: encoding %q: %w", key, err)
+			return
+		}
+		if err = s.Set(key, value); err == nil {
+			n++
+		}
+	})
+	return n, err
+}
+
+// Source is an Iterable of the items decoded from the entries of a
+// Store sharing a key prefix. The entries are read lazily through a
+// Cursor, which is closed once exhausted.
+//
+// Next reports no more items on an error of the Cursor or of the
+// Codec, which is then returned by Err.
+type Source struct {
+	cur    Cursor
+	prefix []byte
+	decode func(key, value []byte) (interface{}, error)
+	err    error
+	items  []interface{}
+	idx    int
+}
+
+// Load creates a Source of the entries of s whose key starts with prefix.
+//
+// Example:
+//   it := iter.New(kv.Load(store, []byte("user/"), codec))
+func Load(s Store, prefix []byte, c Codec) *Source {
+	return &Source{cur: s.Scan(prefix), prefix: prefix, decode: c.Decode}
+}
+
+// New implements iter.Iterable. It returns an in-memory Source,
+// so that the APIs producing new Iterators can store their items.
+func (*Source) New() (iter.Iterable, error) {
+	return &Source{}, nil
+}
+
+// Add implements iter.Iterable. Only the Sources created by New
+// accept items.
+func (src *Source) Add(obj interface{}) {
+	if src.decode != nil {
+		panic("kv: can't Add to a Source reading a Store")
+	}
+	src.items = append(src.items, obj)
+}
+
+// Next implements iter.Iterable.
+func (src *Source) Next() (interface{}, bool) {
+	if src.decode == nil {
+		if src.idx < len(src.items) {
+			src.idx++
+			return src.items[src.idx-1], true
+		}
+		return nil, false
+	}
+	if src.cur == nil {
+		return nil, false
+	}
+
+	key, value, ok := src.cur.Next()
+	if !ok || !bytes.HasPrefix(key, src.prefix) {
+		src.close(src.cur.Err())
+		return nil, false
+	}
+	v, err := src.decode(key, value)
+	if err != nil {
+		src.close(fmt.Errorf("kv: decoding %q: %w", key, err))
+		return nil, false
+	}
+	return v, true
+}
+
+// close closes the Cursor, keeping the first error.
+func (src *Source) close(err error) {
+	if cerr := src.cur.Close(); err == nil {
+		err = cerr
+	}
+	src.cur, src.err = nil, err
+}
+
+// Err returns the error which stopped the Source, if any.
+func (src *Source) Err() error {
+	return src.err
+}
+
+// Close closes the Cursor of a Source which hasn't been read until its
+// end. It's a no-op otherwise.
+func (src *Source) Close() error {
+	if src.cur == nil {
+		return nil
+	}
+	src.close(nil)
+	return src.err
+}
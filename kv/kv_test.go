@@ -0,0 +1,102 @@
+package kv
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	iter "github.com/i3d/goiter"
+)
+
+// store is an in-memory Store.
+type store struct {
+	m    map[string][]byte
+	sets int
+}
+
+func (s *store) Get(key []byte) ([]byte, bool, error) {
+	v, ok := s.m[string(key)]
+	return v, ok, nil
+}
+
+func (s *store) Set(key, value []byte) error {
+	s.m[string(key)] = value
+	s.sets++
+	return nil
+}
+
+func (s *store) Scan(from []byte) Cursor {
+	c := &cursor{}
+	for k := range s.m {
+		if k >= string(from) {
+			c.keys = append(c.keys, k)
+		}
+	}
+	sort.Strings(c.keys)
+	c.s = s
+	return c
+}
+
+type cursor struct {
+	s      *store
+	keys   []string
+	closed bool
+}
+
+func (c *cursor) Next() ([]byte, []byte, bool) {
+	if len(c.keys) == 0 {
+		return nil, nil, false
+	}
+	k := c.keys[0]
+	c.keys = c.keys[1:]
+	return []byte(k), c.s.m[k], true
+}
+
+func (c *cursor) Err() error { return nil }
+
+func (c *cursor) Close() error {
+	c.closed = true
+	return nil
+}
+
+var codec = Codec{
+	Key:    func(v interface{}) []byte { return []byte("word/" + v.(string)) },
+	Encode: func(v interface{}) ([]byte, error) { return []byte(strings.ToUpper(v.(string))), nil },
+	Decode: func(_, value []byte) (interface{}, error) { return string(value), nil },
+}
+
+func TestSaveLoad(t *testing.T) {
+	s := &store{m: map[string][]byte{"other": []byte("x")}}
+	if n, err := Save(s, iter.New(iter.FromStrings([]string{"b", "a"})), codec, false); n != 2 || err != nil {
+		t.Fatalf("Save got %d with error: %v", n, err)
+	}
+	if n, err := Save(s, iter.New(iter.FromStrings([]string{"a", "b", "c"})), codec, true); n != 1 || err != nil || s.sets != 3 {
+		t.Errorf("resumed Save got %d (%d sets) with error: %v, want: 1", n, s.sets, err)
+	}
+
+	src := Load(s, []byte("word/"), codec)
+	var got []interface{}
+	iter.New(src).Each(func(v interface{}) { got = append(got, v) })
+	if fmt.Sprint(got) != "[A B C]" || src.Err() != nil {
+		t.Errorf("Load got: %v with error: %v, want: [A B C]", got, src.Err())
+	}
+}
+
+func TestLoadError(t *testing.T) {
+	s := &store{m: map[string][]byte{"k1": nil, "k2": nil}}
+	bad := errors.New("bad")
+	src := Load(s, []byte("k"), Codec{Decode: func(key, _ []byte) (interface{}, error) {
+		if string(key) == "k2" {
+			return nil, bad
+		}
+		return string(key), nil
+	}})
+	if n := iter.New(src).Count(); n != 1 || !errors.Is(src.Err(), bad) {
+		t.Errorf("Load read %d items with error: %v, want 1 and %v", n, src.Err(), bad)
+	}
+	if src.Close() != nil {
+		t.Errorf("Close after an error got: %v", src.Close())
+	}
+}
@@ -0,0 +1,89 @@
+package iter
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// FromMapKeys creates an Iterable of the keys of m, a map of any type,
+// by reflection. The Iterable is the same as the one of FromSlice for a
+// []K, K being the key type of m.
+//
+// If sorted is true the keys are in ascending order: strings, numbers and
+// time.Time are compared by their value, the other keys by their %v
+// format. Otherwise they are in the unspecified order of a map range.
+// An error is returned if m isn't a map.
+//
+// Example:
+//   src, err := FromMapKeys(map[string]int{"b": 2, "a": 1}, true)
+//   produces "a", "b"
+func FromMapKeys(m interface{}, sorted bool) (Iterable, error) {
+	rv, keys, err := mapKeys(m, sorted)
+	if err != nil {
+		return nil, err
+	}
+	s := reflect.MakeSlice(reflect.SliceOf(rv.Type().Key()), 0, len(keys))
+	s = reflect.Append(s, keys...)
+	return &reflSlice{idx: -1, data: s}, nil
+}
+
+// FromMapValues is like FromMapKeys but creates an Iterable of the
+// values of m, in the order of their keys.
+func FromMapValues(m interface{}, sorted bool) (Iterable, error) {
+	rv, keys, err := mapKeys(m, sorted)
+	if err != nil {
+		return nil, err
+	}
+	s := reflect.MakeSlice(reflect.SliceOf(rv.Type().Elem()), 0, len(keys))
+	for _, k := range keys {
+		s = reflect.Append(s, rv.MapIndex(k))
+	}
+	return &reflSlice{idx: -1, data: s}, nil
+}
+
+// FromMapEntries is like FromMapKeys but creates a Pairs of the entries
+// of m, X being the key and Y the value.
+func FromMapEntries(m interface{}, sorted bool) (*Pairs, error) {
+	rv, keys, err := mapKeys(m, sorted)
+	if err != nil {
+		return nil, err
+	}
+	ps := make([]*Pair, len(keys))
+	for i, k := range keys {
+		ps[i] = &Pair{k.Interface(), rv.MapIndex(k).Interface()}
+	}
+	return FromPairs(ps), nil
+}
+
+// mapKeys returns the value of the map m and its keys, sorted if asked.
+func mapKeys(m interface{}, sorted bool) (reflect.Value, []reflect.Value, error) {
+	rv := reflect.ValueOf(m)
+	if rv.Kind() != reflect.Map {
+		return rv, nil, fmt.Errorf("iter: a map is expected, got %T", m)
+	}
+	keys := rv.MapKeys()
+	if sorted {
+		sort.Slice(keys, func(i, j int) bool { return lessKey(keys[i], keys[j]) })
+	}
+	return rv, keys, nil
+}
+
+// lessKey orders two map keys of the same type.
+func lessKey(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.String:
+		return a.String() < b.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	}
+	if t, ok := a.Interface().(time.Time); ok {
+		return t.Before(b.Interface().(time.Time))
+	}
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
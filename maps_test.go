@@ -0,0 +1,42 @@
+package iter
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFromMap(t *testing.T) {
+	type id int
+	m := map[id]string{3: "c", 1: "a", 2: "b"}
+
+	keys, err := FromMapKeys(m, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := New(keys).Collect().([]id); fmt.Sprint(got) != "[1 2 3]" {
+		t.Errorf("FromMapKeys got: %v, want: [1 2 3]", got)
+	}
+
+	values, err := FromMapValues(m, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := New(values).Collect().([]string); fmt.Sprint(got) != "[a b c]" {
+		t.Errorf("FromMapValues got: %v, want: [a b c]", got)
+	}
+
+	entries, err := FromMapEntries(map[[2]int]bool{{1, 2}: true, {0, 5}: false}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(New(entries).Collect()); got != "[{[0 5], false} {[1 2], true}]" {
+		t.Errorf("FromMapEntries got: %s, want: [{[0 5], false} {[1 2], true}]", got)
+	}
+
+	if unsorted, err := FromMapKeys(m, false); err != nil || New(unsorted).Count() != 3 {
+		t.Errorf("unsorted FromMapKeys got error: %v", err)
+	}
+	if _, err := FromMapKeys([]string{"a"}, true); err == nil {
+		t.Error("FromMapKeys of a slice got no error")
+	}
+}
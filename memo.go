@@ -0,0 +1,189 @@
+package iter
+
+// Memoize returns a new Iterator which records the items on the first
+// traversal and replays them afterwards, so that even a source which isn't
+// a Rewinder (e.g. a channel or network source) can be traversed many
+// times, e.g. Count first then process.
+//
+// Memoize is lazy: an item is read from the source only when the returned
+// Iterator reaches it for the first time. The returned Iterable is a
+// Rewinder, an Enumerator and a FromIter, but it doesn't accept Add.
+//
+// Memoize keeps every item in memory, see MemoizeN for a bounded variant.
+// The returned Iterator has the strict mode, the RewindPolicy and the
+// error of this one.
+//
+// Example:
+//   it := New(oneShotSource).Memoize()
+//   n := it.Count() // reads the source
+//   it.Each(f)      // replays the recorded items
+func (it *Iter) Memoize() *Iter {
+	return newFromImpl(it.impl.wrap(&memo{src: it.impl.item}))
+}
+
+// memo is the Iterable behind Memoize.
+type memo struct {
+	src   Iterable
+	cache []interface{}
+	pos   int
+	done  bool
+	// err is the error of the New API of src, see To.
+	err error
+}
+
+func (m *memo) New() (Iterable, error) {
+	return m.src.New()
+}
+
+func (*memo) Add(interface{}) {
+	panic("iter: Add is not supported by a memoized Iterable")
+}
+
+func (m *memo) Next() (interface{}, bool) {
+	_, v, more := m.Enumerate()
+	return v, more
+}
+
+func (m *memo) Enumerate() (int, interface{}, bool) {
+	if m.pos == len(m.cache) {
+		if m.done {
+			return -1, nil, false
+		}
+		v, more := m.src.Next()
+		if !more {
+			m.done = true
+			return -1, nil, false
+		}
+		m.cache = append(m.cache, v)
+	}
+	i := m.pos
+	m.pos++
+	return i, m.cache[i], true
+}
+
+func (m *memo) Rewind() {
+	m.pos = 0
+}
+
+// To reads the source entirely and returns all the items as created by
+// the source's New API, or by an in-memory Iterable if it fails, see Err.
+func (m *memo) To() interface{} {
+	for m.pos = len(m.cache); !m.done; {
+		m.Next()
+	}
+	newitem, err := newOf(m.src)
+	if err != nil && m.err == nil {
+		m.err = err
+	}
+	for _, v := range m.cache {
+		newitem.Add(v)
+	}
+	return newitem.(FromIter).To()
+}
+
+// Err returns the error of the source, if any, or the one of its New
+// API met by To.
+func (m *memo) Err() error {
+	if err := errOf(m.src); err != nil {
+		return err
+	}
+	return m.err
+}
+
+// MemoizeN is like Memoize but only keeps the last n items read from the
+// source, so a huge one-shot source can be partially replayed without an
+// unbounded memory growth. Rewind goes back to the oldest kept item and
+// Enumerate keeps counting the indexes from the very first item. Same as
+// Memoize, the settings and the error of this Iterator are kept.
+//
+// MemoizeN panics if n is less than 1.
+func (it *Iter) MemoizeN(n int) *Iter {
+	if n < 1 {
+		panic("iter: MemoizeN needs to keep at least 1 item")
+	}
+	return newFromImpl(it.impl.wrap(&boundedMemo{src: it.impl.item, maxItems: n}))
+}
+
+// MemoizeSize is like MemoizeN but bounds the total size of the kept
+// items, as measured by size (e.g. in bytes), to max. The latest item
+// is always kept, even if its size alone exceeds max.
+func (it *Iter) MemoizeSize(max int, size func(interface{}) int) *Iter {
+	return newFromImpl(it.impl.wrap(&boundedMemo{src: it.impl.item, maxSize: max, size: size}))
+}
+
+// boundedMemo is the Iterable behind MemoizeN and MemoizeSize.
+type boundedMemo struct {
+	src      Iterable
+	maxItems int
+	maxSize  int
+	size     func(interface{}) int
+
+	cache []interface{}
+	sizes []int
+	total int
+	// base is the index of cache[0] since the very first item,
+	// pos is the index of the next item to be emitted.
+	base int
+	pos  int
+	done bool
+}
+
+// Err returns the error of the source, if any.
+func (m *boundedMemo) Err() error {
+	return errOf(m.src)
+}
+
+func (m *boundedMemo) New() (Iterable, error) {
+	return m.src.New()
+}
+
+func (*boundedMemo) Add(interface{}) {
+	panic("iter: Add is not supported by a memoized Iterable")
+}
+
+func (m *boundedMemo) Next() (interface{}, bool) {
+	_, v, more := m.Enumerate()
+	return v, more
+}
+
+func (m *boundedMemo) Enumerate() (int, interface{}, bool) {
+	if m.pos == m.base+len(m.cache) {
+		if m.done {
+			return -1, nil, false
+		}
+		v, more := m.src.Next()
+		if !more {
+			m.done = true
+			return -1, nil, false
+		}
+		m.keep(v)
+	}
+	i := m.pos
+	m.pos++
+	return i, m.cache[i-m.base], true
+}
+
+// keep appends v to the cache and evicts the oldest items over the bounds.
+func (m *boundedMemo) keep(v interface{}) {
+	m.cache = append(m.cache, v)
+	if m.size != nil {
+		s := m.size(v)
+		m.sizes = append(m.sizes, s)
+		m.total += s
+	}
+
+	for len(m.cache) > 1 && (m.maxItems > 0 && len(m.cache) > m.maxItems ||
+		m.size != nil && m.total > m.maxSize) {
+		m.cache[0] = nil
+		m.cache = m.cache[1:]
+		if m.size != nil {
+			m.total -= m.sizes[0]
+			m.sizes = m.sizes[1:]
+		}
+		m.base++
+	}
+}
+
+func (m *boundedMemo) Rewind() {
+	m.pos = m.base
+}
@@ -0,0 +1,81 @@
+package iter
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMemoize(t *testing.T) {
+	src := oneShot{FromStrings([]string{"a", "b", "c"})}
+	it := New(src).Memoize()
+
+	if n := it.Count(); n != 3 {
+		t.Errorf("Memoize Count got: %d, want: 3", n)
+	}
+	if n := it.Count(); n != 3 {
+		t.Errorf("Memoize Count again got: %d, want: 3", n)
+	}
+	if v := it.Nth(1); v != "b" {
+		t.Errorf("Memoize Nth(1) got: %v, want: b", v)
+	}
+	if _, more := src.Next(); more {
+		t.Error("Memoize didn't consume the source")
+	}
+
+	var seen []interface{}
+	it.Each(func(v interface{}) { seen = append(seen, v) })
+	if fmt.Sprint(seen) != "[a b c]" {
+		t.Errorf("Memoize replays: %v, want: [a b c]", seen)
+	}
+	if got := fmt.Sprint(it.Filter(func(v interface{}) bool { return v != "b" }).Collect()); got != "[a c]" {
+		t.Errorf("Filter over Memoize got: %s, want: [a c]", got)
+	}
+
+	// The settings and the error of the Iterator are kept.
+	failed := New(unmakeable{FromStrings([]string{"a"})}).Strict().Filter(func(interface{}) bool { return true })
+	memoized := failed.Memoize()
+	if !memoized.impl.strict || !errors.Is(memoized.Err(), failed.Err()) || memoized.Err() == nil {
+		t.Errorf("Memoize got strict: %t, Err: %v, want: true, %v", memoized.impl.strict, memoized.Err(), failed.Err())
+	}
+}
+
+func TestMemoizeBounded(t *testing.T) {
+	replay := func(it *Iter) string {
+		var seen []string
+		it.Each(func(v interface{}) { seen = append(seen, v.(string)) })
+		return fmt.Sprint(seen)
+	}
+
+	it := New(oneShot{FromStrings([]string{"a", "b", "c", "d"})}).MemoizeN(2)
+	if got := replay(it); got != "[a b c d]" {
+		t.Errorf("MemoizeN first traversal got: %s, want: [a b c d]", got)
+	}
+	if got := replay(it); got != "[c d]" {
+		t.Errorf("MemoizeN(2) replays: %s, want: [c d]", got)
+	}
+	if i, v, _ := it.Iterable().(Enumerator).Enumerate(); i != 2 || v != "c" {
+		t.Errorf("MemoizeN(2) Enumerate got: %d, %v, want: 2, c", i, v)
+	}
+
+	bytes := func(v interface{}) int { return len(v.(string)) }
+	it = New(oneShot{FromStrings([]string{"aa", "b", "cc", "ddddd"})}).MemoizeSize(3, bytes)
+	it.Iterable().Next()
+	it.Iterable().Next()
+	it.Iterable().Next()
+	it.Iterable().(Rewinder).Rewind()
+	if got := replay(it); got != "[b cc ddddd]" {
+		t.Errorf("MemoizeSize(3) got: %s, want: [b cc ddddd]", got)
+	}
+	if got := replay(it); got != "[ddddd]" {
+		t.Errorf("MemoizeSize(3) replays: %s, want: [ddddd]", got)
+	}
+
+	// The settings and the error of the Iterator are kept.
+	failed := New(unmakeable{FromStrings([]string{"a"})}).Strict().Filter(func(interface{}) bool { return true })
+	for _, memoized := range []*Iter{failed.MemoizeN(1), failed.MemoizeSize(1, bytes)} {
+		if !memoized.impl.strict || memoized.Err() == nil || memoized.Err() != failed.Err() {
+			t.Errorf("bounded Memoize got strict: %t, Err: %v, want: true, %v", memoized.impl.strict, memoized.Err(), failed.Err())
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package iter
+
+import "container/heap"
+
+// MergeBy multiplexes several Iterables into one Iterator which always
+// emits the smallest head among the sources, e.g. the highest-priority
+// work item when cmp orders by priority.
+//
+// cmp returns a negative number when a shall be emitted before b, a
+// positive number when b shall go first and zero when they are equal.
+// Equal heads are emitted in the order of their sources.
+//
+// The sources are not required to be sorted individually: only their
+// heads are compared, so a sorted output is only guaranteed when every
+// source is sorted. MergeBy is lazy, each source is read one item ahead.
+//
+// The returned Iterable doesn't accept Add. It is a Rewinder which rewinds
+// the sources, so it can only be traversed again if every source is a
+// Rewinder.
+//
+// Example:
+//   MergeBy(func(a, b interface{}) int { return strings.Compare(a.(string), b.(string)) },
+//      FromStrings([]string{"a", "d"}),
+//      FromStrings([]string{"b", "c"}))
+//   produces "a", "b", "c", "d"
+func MergeBy(cmp func(a, b interface{}) int, sources ...Iterable) *Iter {
+	return New(&merged{sources: sources, heads: heads{cmp: cmp}})
+}
+
+// merged is the lazy Iterable behind MergeBy.
+type merged struct {
+	sources []Iterable
+	started bool
+	heads   heads
+}
+
+// Err returns the first error of the sources, if any.
+func (m *merged) Err() error {
+	return errOf(m.sources...)
+}
+
+func (*merged) New() (Iterable, error) {
+	return newElems()
+}
+
+func (*merged) Add(interface{}) {
+	panic("iter: Add is not supported by a merged Iterable")
+}
+
+func (m *merged) Next() (interface{}, bool) {
+	if !m.started {
+		m.started = true
+		for i, src := range m.sources {
+			if v, more := src.Next(); more {
+				m.heads.items = append(m.heads.items, head{v, i})
+			}
+		}
+		heap.Init(&m.heads)
+	}
+
+	if m.heads.Len() == 0 {
+		return nil, false
+	}
+	h := m.heads.items[0]
+	if v, more := m.sources[h.src].Next(); more {
+		m.heads.items[0].v = v
+		heap.Fix(&m.heads, 0)
+	} else {
+		heap.Pop(&m.heads)
+	}
+	return h.v, true
+}
+
+func (m *merged) Rewind() {
+	for _, src := range m.sources {
+		if r, ok := src.(Rewinder); ok {
+			r.Rewind()
+		}
+	}
+	m.started = false
+	m.heads.items = m.heads.items[:0]
+}
+
+// head is the next item of the source src.
+type head struct {
+	v   interface{}
+	src int
+}
+
+// heads implements heap.Interface.
+type heads struct {
+	cmp   func(a, b interface{}) int
+	items []head
+}
+
+func (hs *heads) Len() int { return len(hs.items) }
+
+func (hs *heads) Less(i, j int) bool {
+	if c := hs.cmp(hs.items[i].v, hs.items[j].v); c != 0 {
+		return c < 0
+	}
+	return hs.items[i].src < hs.items[j].src
+}
+
+func (hs *heads) Swap(i, j int) { hs.items[i], hs.items[j] = hs.items[j], hs.items[i] }
+
+func (hs *heads) Push(x interface{}) { hs.items = append(hs.items, x.(head)) }
+
+func (hs *heads) Pop() interface{} {
+	last := hs.items[len(hs.items)-1]
+	hs.items = hs.items[:len(hs.items)-1]
+	return last
+}
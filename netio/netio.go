@@ -0,0 +1,101 @@
+// Package netio reads network connections as Iterables of the
+// github.com/i3d/goiter package, e.g. the lines of a simple TCP protocol,
+// with the idle timeouts handled by the source.
+package netio
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"time"
+
+	"github.com/i3d/goiter/internal/readsrc"
+)
+
+// Source is an Iterable of the messages read from a connection, read
+// lazily. The connection is closed once read until its end, Close closes
+// it before.
+//
+// Next reports no more items on an error, e.g. an idle timeout, which is
+// then returned by Err. The Iterables created by New are in-memory
+// Rewinders.
+type Source struct {
+	*readsrc.Source
+}
+
+// connSource creates a Source reading c by read.
+func connSource(c io.Closer, read func() (interface{}, error)) *Source {
+	return &Source{readsrc.New("netio", "a connection", closer{c}, read)}
+}
+
+// closer closes a connection, one already closed, e.g. to stop the
+// Source, isn't an error.
+type closer struct {
+	c io.Closer
+}
+
+func (c closer) Close() error {
+	if err := c.c.Close(); !errors.Is(err, net.ErrClosed) {
+		return err
+	}
+	return nil
+}
+
+// FromConnLines creates a Source of the lines read from conn, as strings
+// without their line terminator.
+//
+// When idleTimeout isn't zero, the Source ends with a timeout error, see
+// net.Error, once no line is received within idleTimeout.
+//
+// Example:
+//   src := netio.FromConnLines(conn, time.Minute)
+//   iter.New(src).Each(handleCommand)
+//   if err := src.Err(); err != nil {
+//      log.Print(err)
+//   }
+func FromConnLines(conn net.Conn, idleTimeout time.Duration) *Source {
+	read := delimited(conn, '\n', idleTimeout)
+	return connSource(conn, func() (interface{}, error) {
+		b, err := read()
+		if err != nil {
+			return nil, err
+		}
+		return string(bytes.TrimSuffix(b, []byte{'\r'})), nil
+	})
+}
+
+// FromConnDelimited is like FromConnLines but the messages end with
+// delim, e.g. 0 for NUL terminated ones, and are read as []byte without
+// their delimiter.
+func FromConnDelimited(conn net.Conn, delim byte, idleTimeout time.Duration) *Source {
+	read := delimited(conn, delim, idleTimeout)
+	return connSource(conn, func() (interface{}, error) {
+		b, err := read()
+		if err != nil {
+			return nil, err
+		}
+		return b, nil
+	})
+}
+
+// delimited reads the messages of conn ending with delim, the last one
+// may end with the connection instead.
+func delimited(conn net.Conn, delim byte, idleTimeout time.Duration) func() ([]byte, error) {
+	r := bufio.NewReader(conn)
+	return func() ([]byte, error) {
+		if idleTimeout != 0 {
+			// An error, e.g. on a closed connection, is reported by the read.
+			conn.SetReadDeadline(time.Now().Add(idleTimeout))
+		}
+		b, err := r.ReadBytes(delim)
+		if err == nil {
+			return b[:len(b)-1], nil
+		}
+		if errors.Is(err, io.EOF) && len(b) > 0 {
+			return b, nil
+		}
+		return nil, err
+	}
+}
@@ -0,0 +1,48 @@
+package netio
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	iter "github.com/i3d/goiter"
+)
+
+func TestFromConnLines(t *testing.T) {
+	client, server := net.Pipe()
+	go func() {
+		client.Write([]byte("HELO a\r\nQUIT"))
+		client.Close()
+	}()
+
+	var got []interface{}
+	src := FromConnLines(server, time.Second)
+	iter.New(src).Each(func(v interface{}) { got = append(got, v) })
+	if fmt.Sprintf("%q", got) != `["HELO a" "QUIT"]` {
+		t.Errorf("FromConnLines got: %q, want: [\"HELO a\" \"QUIT\"]", got)
+	}
+	if err := src.Err(); err != nil {
+		t.Errorf("FromConnLines got error: %v", err)
+	}
+}
+
+func TestFromConnDelimited(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	go client.Write([]byte("a\x00bc\x00"))
+
+	src := FromConnDelimited(server, 0, 10*time.Millisecond)
+	for _, want := range []string{"a", "bc"} {
+		if v, more := src.Next(); !more || string(v.([]byte)) != want {
+			t.Fatalf("FromConnDelimited got: %v, %v, want: %s, true", v, more, want)
+		}
+	}
+
+	// No more messages: the idle timeout stops the Source.
+	var nerr net.Error
+	if _, more := src.Next(); more || !errors.As(src.Err(), &nerr) || !nerr.Timeout() {
+		t.Errorf("FromConnDelimited got more: %v, error: %v, want a timeout", more, src.Err())
+	}
+}
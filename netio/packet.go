@@ -0,0 +1,41 @@
+package netio
+
+import (
+	"errors"
+	"io"
+	"net"
+
+	iter "github.com/i3d/goiter"
+)
+
+// FromPacketConn creates a Source of the datagrams received by pc, e.g. a
+// UDP socket, as *iter.Pair whose X is the net.Addr of the sender and Y
+// the payload, as []byte. A datagram larger than bufSize is truncated, a
+// zero bufSize makes room for the largest UDP payload.
+//
+// The Source ends, without any error, once pc is closed, e.g. by another
+// goroutine.
+//
+// Example:
+//   pc, err := net.ListenPacket("udp", ":514")
+//   if err != nil {
+//      return err
+//   }
+//   src := netio.FromPacketConn(pc, 0)
+//   iter.New(src).Filter(fromTrustedHost).Each(handleSyslog)
+func FromPacketConn(pc net.PacketConn, bufSize int) *Source {
+	if bufSize == 0 {
+		bufSize = 65535
+	}
+	buf := make([]byte, bufSize)
+	return connSource(pc, func() (interface{}, error) {
+		n, addr, err := pc.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				err = io.EOF
+			}
+			return nil, err
+		}
+		return &iter.Pair{X: addr, Y: append([]byte(nil), buf[:n]...)}, nil
+	})
+}
@@ -0,0 +1,36 @@
+package netio
+
+import (
+	"net"
+	"testing"
+
+	iter "github.com/i3d/goiter"
+)
+
+func TestFromPacketConn(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip("no UDP:", err)
+	}
+	conn, err := net.Dial("udp", pc.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	src := FromPacketConn(pc, 4)
+	conn.Write([]byte("ping"))
+	v, more := src.Next()
+	if !more {
+		t.Fatalf("FromPacketConn got no datagram, error: %v", src.Err())
+	}
+	p := v.(*iter.Pair)
+	if string(p.Y.([]byte)) != "ping" || p.X.(net.Addr).String() != conn.LocalAddr().String() {
+		t.Errorf("FromPacketConn got: {%v, %s}, want: {%v, ping}", p.X, p.Y, conn.LocalAddr())
+	}
+
+	pc.Close()
+	if _, more := src.Next(); more || src.Err() != nil {
+		t.Errorf("FromPacketConn after Close got more: %v, error: %v", more, src.Err())
+	}
+}
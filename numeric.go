@@ -0,0 +1,336 @@
+package iter
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// NormalizeMode selects the scaling applied by Normalize.
+type NormalizeMode int
+
+const (
+	// MinMax rescales every item into the range [0, 1]
+	// using the minimum and maximum of the Iterable.
+	MinMax NormalizeMode = iota
+	// ZScore rescales every item to its distance from the mean
+	// measured in standard deviations.
+	ZScore
+)
+
+// Normalize rescales every numeric item of the Iterable according to the
+// given mode and returns a new Iterator contains float64 items.
+//
+// If the underlying Iterable is a Rewinder, Normalize makes two passes:
+// the first one collects the statistics (min/max or mean/stddev) of the
+// whole Iterable and the second one rescales every item against them.
+// Otherwise, the statistics are estimated on the fly, meaning an item is
+// rescaled against what has been seen so far (including itself).
+//
+// Items are expected to be one of Go's builtin integer or float types,
+// any other type panics.
+//
+// Example:
+//   it := New(FromInts([]int{0, 5, 10}))
+//   newit := it.Normalize(MinMax)
+//   produces a newit contains []interface{}{0.0, 0.5, 1.0}
+func (it *Iter) Normalize(mode NormalizeMode) *Iter {
+	return newFromImpl(it.impl.normalize(mode))
+}
+
+func (it *iter) normalize(mode NormalizeMode) *iter {
+	newitem, _ := newElems()
+
+	var st stats
+	_, rewindable := it.item.(Rewinder)
+	if rewindable {
+		it.scan(func(v interface{}) {
+			st.add(asFloat(v))
+		})
+	}
+
+	var n int
+	for {
+		elm, more := it.next()
+		if !more {
+			break
+		}
+		f := asFloat(elm)
+		if !rewindable {
+			st.add(f)
+		}
+		newitem.Add(st.scale(mode, f))
+		n++
+	}
+	return it.derive("Normalize", newitem, n, n)
+}
+
+// Bucketize maps every numeric item of the Iterable to the label of the
+// bucket it falls into and returns a new Iterator contains those labels.
+//
+// bounds must be sorted in ascending order and labels must have exactly
+// one more element than bounds, otherwise Bucketize panics. An item v
+// gets labels[i] where i is the number of bounds that are <= v, in other
+// words, bucket i covers [bounds[i-1], bounds[i]).
+//
+// Example:
+//   it := New(FromInts([]int{1, 15, 30}))
+//   newit := it.Bucketize([]float64{10, 20}, []interface{}{"low", "mid", "high"})
+//   produces a newit contains []interface{}{"low", "mid", "high"}
+func (it *Iter) Bucketize(bounds []float64, labels []interface{}) *Iter {
+	return newFromImpl(it.impl.bucketize(bounds, labels))
+}
+
+func (it *iter) bucketize(bounds []float64, labels []interface{}) *iter {
+	if len(labels) != len(bounds)+1 {
+		panic(fmt.Sprintf("iter: Bucketize needs %d labels for %d bounds, got %d",
+			len(bounds)+1, len(bounds), len(labels)))
+	}
+	newitem, _ := newElems()
+
+	var n int
+	for {
+		elm, more := it.next()
+		if !more {
+			break
+		}
+		f := asFloat(elm)
+		i := sort.Search(len(bounds), func(i int) bool { return bounds[i] > f })
+		newitem.Add(labels[i])
+		n++
+	}
+	return it.derive("Bucketize", newitem, n, n)
+}
+
+// Clamp bounds every item of the Iterable into the range [min, max] and
+// returns a new Iterator contains the bounded items.
+//
+// value extracts the number to be checked from an item. Items within the
+// range are kept as they are, whereas an item out of the range is replaced
+// by whatever rebuild returns given the item and its clamped value. This
+// allows clamping a field of a struct item without losing the other fields.
+//
+// Clamp panics if min is greater than max.
+//
+// Example:
+//   it := New(FromInts([]int{-3, 5, 42}))
+//   newit := it.Clamp(0, 10,
+//      func(v interface{}) float64 { return float64(v.(int)) },
+//      func(_ interface{}, f float64) interface{} { return int(f) })
+//   produces a newit contains []int{0, 5, 10}
+func (it *Iter) Clamp(min, max float64, value func(interface{}) float64, rebuild func(old interface{}, clamped float64) interface{}) *Iter {
+	return newFromImpl(it.impl.clamp(min, max, value, rebuild))
+}
+
+func (it *iter) clamp(min, max float64, value func(interface{}) float64, rebuild func(interface{}, float64) interface{}) *iter {
+	if min > max {
+		panic(fmt.Sprintf("iter: Clamp min %v is greater than max %v", min, max))
+	}
+	newitem := it.newItem()
+
+	var n int
+	for {
+		elm, more := it.next()
+		if !more {
+			break
+		}
+		switch f := value(elm); {
+		case f < min:
+			newitem.Add(rebuild(elm, min))
+		case f > max:
+			newitem.Add(rebuild(elm, max))
+		default:
+			newitem.Add(elm)
+		}
+		n++
+	}
+	return it.derive("Clamp", newitem, n, n)
+}
+
+// FilterOutliers drops every item which is more than k standard deviations
+// away from the mean and returns a new Iterator contains the rest.
+//
+// value extracts the number to be checked from an item.
+// If the underlying Iterable is a Rewinder, the mean and the standard
+// deviation are computed over the whole Iterable in a prior pass.
+// Otherwise, they are estimated from the items seen before the checked
+// one, so the very first items of a stream are never dropped.
+//
+// Example:
+//   it := New(FromInts([]int{10, 11, 9, 10, 500}))
+//   newit := it.FilterOutliers(1.5, func(v interface{}) float64 {
+//      return float64(v.(int))
+//   })
+//   produces a newit contains []int{10, 11, 9, 10}
+func (it *Iter) FilterOutliers(k float64, value func(interface{}) float64) *Iter {
+	newitem := it.impl.newItem()
+	var read, emitted int
+	it.impl.outliers(k, value, func(v interface{}, outlier bool) {
+		read++
+		if !outlier {
+			newitem.Add(v)
+			emitted++
+		}
+	})
+	return newFromImpl(it.impl.derive("FilterOutliers", newitem, read, emitted))
+}
+
+// FlagOutliers is like FilterOutliers but keeps every item. It returns a
+// new Iterator of *Pair where X is the item and Y is a bool indicates
+// whether the item is an outlier.
+func (it *Iter) FlagOutliers(k float64, value func(interface{}) float64) *Iter {
+	np := NewPairs()
+	var n int
+	it.impl.outliers(k, value, func(v interface{}, outlier bool) {
+		np.Add(&Pair{v, outlier})
+		n++
+	})
+	return newFromImpl(it.impl.derive("FlagOutliers", np, n, n))
+}
+
+func (it *iter) outliers(k float64, value func(interface{}) float64, f func(interface{}, bool)) {
+	var st stats
+	_, rewindable := it.item.(Rewinder)
+	if rewindable {
+		it.scan(func(v interface{}) {
+			st.add(value(v))
+		})
+	}
+
+	for {
+		elm, more := it.next()
+		if !more {
+			break
+		}
+		v := value(elm)
+		sd := st.stddev()
+		f(elm, sd > 0 && math.Abs(v-st.mean) > k*sd)
+		if !rewindable {
+			st.add(v)
+		}
+	}
+}
+
+// stats keeps the running statistics of a numeric stream.
+// mean and m2 are updated with Welford's algorithm so that
+// the variance stays numerically stable on long streams.
+type stats struct {
+	n    int
+	min  float64
+	max  float64
+	mean float64
+	m2   float64
+}
+
+func (st *stats) add(f float64) {
+	st.n++
+	if st.n == 1 || f < st.min {
+		st.min = f
+	}
+	if st.n == 1 || f > st.max {
+		st.max = f
+	}
+	delta := f - st.mean
+	st.mean += delta / float64(st.n)
+	st.m2 += delta * (f - st.mean)
+}
+
+// stddev returns the population standard deviation.
+func (st *stats) stddev() float64 {
+	if st.n == 0 {
+		return 0
+	}
+	return math.Sqrt(st.m2 / float64(st.n))
+}
+
+func (st *stats) scale(mode NormalizeMode, f float64) float64 {
+	switch mode {
+	case MinMax:
+		if st.max == st.min {
+			return 0
+		}
+		return (f - st.min) / (st.max - st.min)
+	case ZScore:
+		sd := st.stddev()
+		if sd == 0 {
+			return 0
+		}
+		return (f - st.mean) / sd
+	}
+	panic(fmt.Sprintf("iter: unknown NormalizeMode %d", mode))
+}
+
+// WeightedSum returns the sum of X*Y over the *Pair items of the
+// Iterable, where X is a value and Y its weight, e.g. after zipping the
+// values with their weights. X and Y are expected to be numbers, same as
+// Normalize. Same as Each, the Iterable is rewinded afterwards if it is
+// a Rewinder.
+//
+// Example:
+//   it := New(FromInts([]int{10, 20})).Zip(FromInts([]int{1, 3}))
+//   it.WeightedSum() => 70
+func (it *Iter) WeightedSum() float64 {
+	sum, _ := it.impl.weighted()
+	return sum
+}
+
+// WeightedMean is like WeightedSum but divides the sum by the total
+// weight, it returns NaN when the total weight is zero, e.g. for an
+// empty Iterable.
+//
+// Example:
+//   it := New(FromInts([]int{10, 20})).Zip(FromInts([]int{1, 3}))
+//   it.WeightedMean() => 17.5
+func (it *Iter) WeightedMean() float64 {
+	sum, weights := it.impl.weighted()
+	if weights == 0 {
+		return math.NaN()
+	}
+	return sum / weights
+}
+
+// weighted returns the weighted sum and the total weight.
+func (it *iter) weighted() (float64, float64) {
+	if !it.guard() {
+		return 0, 0
+	}
+	var sum, weights float64
+	it.each(func(v interface{}) {
+		p := v.(*Pair)
+		w := asFloat(p.Y)
+		sum += asFloat(p.X) * w
+		weights += w
+	})
+	return sum, weights
+}
+
+// asFloat converts a numeric item into a float64.
+func asFloat(v interface{}) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case float32:
+		return float64(n)
+	case int:
+		return float64(n)
+	case int8:
+		return float64(n)
+	case int16:
+		return float64(n)
+	case int32:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case uint:
+		return float64(n)
+	case uint8:
+		return float64(n)
+	case uint16:
+		return float64(n)
+	case uint32:
+		return float64(n)
+	case uint64:
+		return float64(n)
+	}
+	panic(fmt.Sprintf("iter: %#v (%T) is not a number", v, v))
+}
@@ -0,0 +1,138 @@
+package iter
+
+import (
+	"math"
+	"testing"
+)
+
+// oneShot hides every optional interface of the embedded Iterable,
+// so it behaves like a source which can only be traversed once.
+type oneShot struct {
+	Iterable
+}
+
+func floatsEqual(got []interface{}, want []float64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range want {
+		if math.Abs(got[i].(float64)-want[i]) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		desc string
+		src  Iterable
+		mode NormalizeMode
+		want []float64
+	}{
+		{"minmax", FromInts([]int{0, 5, 10}), MinMax, []float64{0, 0.5, 1}},
+		{"minmax-same", FromInts([]int{3, 3}), MinMax, []float64{0, 0}},
+		{"zscore", FromInts([]int{2, 4, 4, 4, 5, 5, 7, 9}), ZScore, []float64{-1.5, -0.5, -0.5, -0.5, 0, 0, 1, 2}},
+		{"minmax-streaming", oneShot{FromInts([]int{5, 0, 10})}, MinMax, []float64{0, 0, 1}},
+		{"empty", NewIterInts(), ZScore, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := New(tc.src).Normalize(tc.mode).Collect().([]interface{})
+			if !floatsEqual(got, tc.want) {
+				t.Errorf("Normalize(%d) got: %v, want: %v", tc.mode, got, tc.want)
+			}
+		})
+	}
+	// The two passes of a stage are not traversals of the caller.
+	got := New(FromInts([]int{0, 5, 10})).WithRewind(NeverRewind).Normalize(MinMax).Collect().([]interface{})
+	if want := []float64{0, 0.5, 1}; !floatsEqual(got, want) {
+		t.Errorf("Normalize with NeverRewind got: %v, want: %v", got, want)
+	}
+}
+
+func TestBucketize(t *testing.T) {
+	it := New(FromInts([]int{-5, 0, 9, 10, 19, 20, 100}))
+	got := it.Bucketize([]float64{10, 20}, []interface{}{"low", "mid", "high"}).
+		Collect().([]interface{})
+	want := []string{"low", "low", "low", "mid", "mid", "high", "high"}
+
+	if len(got) != len(want) {
+		t.Fatalf("Bucketize got: %v, want: %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Bucketize got: %v, want: %v", got, want)
+			break
+		}
+	}
+}
+
+func TestClamp(t *testing.T) {
+	ints := FromInts([]int{-3, 5, 42, 10})
+	var rebuilt []interface{}
+	out := New(ints).Clamp(0, 10,
+		func(v interface{}) float64 { return float64(v.(int)) },
+		func(old interface{}, f float64) interface{} {
+			rebuilt = append(rebuilt, old)
+			return int(f)
+		})
+
+	got := out.impl.item.(*IterInts).data
+	if len(got) != 4 || got[0] != 0 || got[1] != 5 || got[2] != 10 || got[3] != 10 {
+		t.Errorf("Clamp got: %v, want: [0 5 10 10]", got)
+	}
+	if len(rebuilt) != 2 || rebuilt[0] != -3 || rebuilt[1] != 42 {
+		t.Errorf("Clamp rebuilt items: %v, want only the out of range ones [-3 42]", rebuilt)
+	}
+}
+
+func TestOutliers(t *testing.T) {
+	value := func(v interface{}) float64 { return float64(v.(int)) }
+
+	t.Run("filter", func(t *testing.T) {
+		out := New(FromInts([]int{10, 11, 9, 10, 500})).FilterOutliers(1.5, value)
+		got := out.impl.item.(*IterInts).data
+		if len(got) != 4 || got[3] != 10 {
+			t.Errorf("FilterOutliers got: %v, want: [10 11 9 10]", got)
+		}
+	})
+
+	t.Run("filter-never-rewind", func(t *testing.T) {
+		out := New(FromInts([]int{10, 11, 9, 10, 500})).WithRewind(NeverRewind).FilterOutliers(1.5, value)
+		got := out.impl.item.(*IterInts).data
+		if len(got) != 4 || got[3] != 10 {
+			t.Errorf("FilterOutliers with NeverRewind got: %v, want: [10 11 9 10]", got)
+		}
+	})
+
+	t.Run("filter-streaming", func(t *testing.T) {
+		out := New(oneShot{FromInts([]int{10, 11, 9, 10, 500, 10})}).FilterOutliers(3, value)
+		got := out.impl.item.(*IterInts).data
+		if len(got) != 5 || got[4] != 10 {
+			t.Errorf("FilterOutliers on a stream got: %v, want: [10 11 9 10 10]", got)
+		}
+	})
+
+	t.Run("flag", func(t *testing.T) {
+		got := New(FromInts([]int{10, 11, 9, 10, 500})).FlagOutliers(1.5, value).Collect().([]*Pair)
+		for i, p := range got {
+			if want := i == 4; p.Y.(bool) != want {
+				t.Errorf("FlagOutliers flagged %v as %t, want: %t", p.X, p.Y, want)
+			}
+		}
+	})
+}
+
+func TestWeighted(t *testing.T) {
+	it := New(FromInts([]int{10, 20})).Zip(FromInts([]int{1, 3}))
+	if sum, mean := it.WeightedSum(), it.WeightedMean(); sum != 70 || mean != 17.5 {
+		t.Errorf("WeightedSum and WeightedMean got: %v and %v, want: 70 and 17.5", sum, mean)
+	}
+
+	empty := New(NewIterInts()).Zip(NewIterInts())
+	if sum, mean := empty.WeightedSum(), empty.WeightedMean(); sum != 0 || !math.IsNaN(mean) {
+		t.Errorf("empty WeightedSum and WeightedMean got: %v and %v, want: 0 and NaN", sum, mean)
+	}
+}
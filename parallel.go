@@ -0,0 +1,270 @@
+package iter
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ParMap is like Map but applies f on workers goroutines, e.g. for a
+// CPU-bound transform over a large Iterable. The returned Iterator
+// contains the results in the order of the source items.
+//
+// The source is read on the calling goroutine, so the Iterable needs not
+// be thread-safe, but f must be. A panic of f is raised again on the
+// calling goroutine. ParMap panics if workers isn't positive.
+//
+// Example:
+//   it := New(FromStrings(paths))
+//   newit := it.ParMap(8, func(v interface{}) interface{} {
+//      return checksum(v.(string))
+//   })
+func (it *Iter) ParMap(workers int, f MapFunc) *Iter {
+	return newFromImpl(it.impl.parMap(workers, f))
+}
+
+func (it *iter) parMap(workers int, f MapFunc) *iter {
+	if workers <= 0 {
+		panic(fmt.Sprintf("iter: ParMap needs a positive number of workers, got %d", workers))
+	}
+
+	var items []interface{}
+	for {
+		elm, more := it.next()
+		if !more {
+			break
+		}
+		items = append(items, elm)
+	}
+
+	out := make([]interface{}, len(items))
+	fanOut(workers, func(emit func(interface{}) bool) {
+		for i := range items {
+			if !emit(i) {
+				return
+			}
+		}
+	}, func(i interface{}) {
+		out[i.(int)] = f(items[i.(int)])
+	})
+
+	newitem := it.newItem()
+	for _, v := range out {
+		newitem.Add(v)
+	}
+	return it.derive("ParMap", newitem, len(items), len(items))
+}
+
+// ParEach is like Each but runs f on workers goroutines, without any
+// ordering guarantee, e.g. for an HTTP call per item. Items are handed
+// to the workers as they are read, so the Iterable is never held in
+// memory.
+//
+// The source is read on the calling goroutine, so the Iterable needs not
+// be thread-safe, but f must be. ParEach returns once f has returned for
+// every item. A panic of f stops handing out items and is raised again on
+// the calling goroutine. ParEach panics if workers isn't positive.
+//
+// Example:
+//   New(FromStrings(urls)).ParEach(16, func(v interface{}) {
+//      ping(v.(string))
+//   })
+func (it *Iter) ParEach(workers int, f EachFunc) {
+	if workers <= 0 {
+		panic(fmt.Sprintf("iter: ParEach needs a positive number of workers, got %d", workers))
+	}
+	if !it.impl.guard() {
+		return
+	}
+	fanOut(workers, func(emit func(interface{}) bool) {
+		defer it.impl.rewind()
+		for {
+			v, more := it.impl.next()
+			if !more || !emit(v) {
+				return
+			}
+		}
+	}, f)
+}
+
+// fanOut runs f on workers goroutines for every item emitted by feed,
+// which runs on the calling goroutine, and waits for them. The first
+// panic of f stops running f and is raised again once all the goroutines
+// have exited. emit returns false once f has panicked, feed is expected
+// to stop emitting then, so that an unbounded source isn't read forever.
+func fanOut(workers int, feed func(emit func(interface{}) bool), f func(interface{})) {
+	items := make(chan interface{})
+	var wg sync.WaitGroup
+	var once sync.Once
+	var panicked interface{}
+	var stopped int32
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range items {
+				if atomic.LoadInt32(&stopped) != 0 {
+					continue
+				}
+				func() {
+					defer func() {
+						if r := recover(); r != nil {
+							once.Do(func() { panicked = r })
+							atomic.StoreInt32(&stopped, 1)
+						}
+					}()
+					f(v)
+				}()
+			}
+		}()
+	}
+	feed(func(v interface{}) bool {
+		if atomic.LoadInt32(&stopped) != 0 {
+			return false
+		}
+		items <- v
+		return true
+	})
+	close(items)
+	wg.Wait()
+
+	if panicked != nil {
+		panic(panicked)
+	}
+}
+
+// AdaptiveOptions configures ParMapAdaptive and ParEachAdaptive.
+type AdaptiveOptions struct {
+	// Min is the lowest number of concurrent calls, and the starting one,
+	// 1 if zero.
+	Min int
+	// Max is the highest number of concurrent calls, it must be positive.
+	Max int
+	// Window is the number of calls the latency is averaged over before
+	// every adjustment, 16 if zero.
+	Window int
+	// Tolerance is how much the average latency may grow over the
+	// baseline before backing off, 0.5 (50%) if zero.
+	Tolerance float64
+	// OnAdjust, if not nil, is called with every new number of
+	// concurrent calls, e.g. to export it as a metric.
+	OnAdjust func(workers int)
+}
+
+// ParMapAdaptive is like ParMap but tunes the number of concurrent calls
+// of f at runtime, AIMD style, e.g. for a service whose latency varies
+// with its load: after every window of calls, the concurrency grows by
+// one while the average latency stays within the tolerance of the
+// baseline, and is halved once it doesn't. The baseline is the lowest
+// average seen since the last back off. ParMapAdaptive panics if
+// opts.Max isn't positive.
+//
+// Example:
+//   newit := it.ParMapAdaptive(AdaptiveOptions{Max: 64}, lookup)
+func (it *Iter) ParMapAdaptive(opts AdaptiveOptions, f MapFunc) *Iter {
+	a := newAIMD("ParMapAdaptive", opts)
+	return newFromImpl(it.impl.parMap(a.max, func(v interface{}) interface{} {
+		defer a.release(a.acquire())
+		return f(v)
+	}))
+}
+
+// ParEachAdaptive is like ParEach but tunes the number of concurrent
+// calls of f at runtime, see ParMapAdaptive.
+func (it *Iter) ParEachAdaptive(opts AdaptiveOptions, f EachFunc) {
+	a := newAIMD("ParEachAdaptive", opts)
+	it.ParEach(a.max, func(v interface{}) {
+		defer a.release(a.acquire())
+		f(v)
+	})
+}
+
+// aimd limits the number of concurrent calls, adjusting the limit by
+// additive increase and multiplicative decrease on their latency.
+type aimd struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	min, max, window int
+	tolerance        float64
+	onAdjust         func(int)
+
+	limit, active int
+	n             int
+	sum, baseline time.Duration
+}
+
+func newAIMD(api string, opts AdaptiveOptions) *aimd {
+	if opts.Max <= 0 {
+		panic(fmt.Sprintf("iter: %s needs a positive maximum of workers, got %d", api, opts.Max))
+	}
+	a := &aimd{min: opts.Min, max: opts.Max, window: opts.Window, tolerance: opts.Tolerance, onAdjust: opts.OnAdjust}
+	if a.min <= 0 {
+		a.min = 1
+	}
+	if a.min > a.max {
+		a.min = a.max
+	}
+	if a.window <= 0 {
+		a.window = 16
+	}
+	if a.tolerance <= 0 {
+		a.tolerance = 0.5
+	}
+	a.limit = a.min
+	a.cond = sync.NewCond(&a.mu)
+	return a
+}
+
+// acquire waits for a free slot and returns the start of the call.
+func (a *aimd) acquire() time.Time {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for a.active >= a.limit {
+		a.cond.Wait()
+	}
+	a.active++
+	return time.Now()
+}
+
+// release frees the slot of a call started at start, adjusting the limit
+// at the end of a window.
+func (a *aimd) release(start time.Time) {
+	d := time.Since(start)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.active--
+	a.n++
+	a.sum += d
+	if a.n == a.window {
+		a.adjust(a.sum / time.Duration(a.n))
+		a.n, a.sum = 0, 0
+	}
+	a.cond.Broadcast()
+}
+
+func (a *aimd) adjust(avg time.Duration) {
+	limit := a.limit
+	switch {
+	case a.baseline == 0 || avg < a.baseline:
+		a.baseline = avg
+		fallthrough
+	case float64(avg) <= float64(a.baseline)*(1+a.tolerance):
+		if limit < a.max {
+			limit++
+		}
+	default:
+		if limit /= 2; limit < a.min {
+			limit = a.min
+		}
+		a.baseline = avg
+	}
+	if limit != a.limit {
+		a.limit = limit
+		if a.onAdjust != nil {
+			a.onAdjust(limit)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package iter
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParMap(t *testing.T) {
+	var in []string
+	for i := 0; i < 50; i++ {
+		in = append(in, fmt.Sprint(i))
+	}
+
+	var mu sync.Mutex
+	var running, peak int
+	got := New(FromStrings(in)).ParMap(4, func(v interface{}) interface{} {
+		mu.Lock()
+		running++
+		if running > peak {
+			peak = running
+		}
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return v.(string) + "!"
+	}).Collect().([]string)
+
+	if len(got) != len(in) {
+		t.Fatalf("ParMap got %d items, want: %d", len(got), len(in))
+	}
+	for i, v := range got {
+		if v != in[i]+"!" {
+			t.Fatalf("ParMap got item %d: %s, want: %s!", i, v, in[i])
+		}
+	}
+	if peak < 2 || peak > 4 {
+		t.Errorf("ParMap ran %d workers at most, want: 2 to 4", peak)
+	}
+
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "boom") {
+			t.Errorf("ParMap with a panicking func got: %v, want: boom", r)
+		}
+	}()
+	New(FromStrings(in)).ParMap(3, func(v interface{}) interface{} {
+		if v.(string) == "7" {
+			panic("boom")
+		}
+		return v
+	})
+}
+
+func TestParEach(t *testing.T) {
+	var in []string
+	for i := 0; i < 100; i++ {
+		in = append(in, fmt.Sprint(i))
+	}
+
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	it := New(FromStrings(in))
+	it.ParEach(8, func(v interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen[v.(string)] = true
+	})
+	if len(seen) != len(in) {
+		t.Errorf("ParEach saw %d distinct items, want: %d", len(seen), len(in))
+	}
+	if it.Position() != 0 {
+		t.Errorf("ParEach didn't rewind, position: %d", it.Position())
+	}
+
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "boom") {
+			t.Errorf("ParEach with a panicking func got: %v, want: boom", r)
+		}
+	}()
+	// The source is unbounded, ParEach has to stop reading it.
+	New(Repeat("x")).ParEach(2, func(v interface{}) {
+		panic("boom")
+	})
+}
+
+func TestParAdaptive(t *testing.T) {
+	// A service which slows down past 4 concurrent calls.
+	var mu sync.Mutex
+	var active, peak int
+	call := func() {
+		mu.Lock()
+		active++
+		if active > peak {
+			peak = active
+		}
+		slow := active > 4
+		mu.Unlock()
+		if slow {
+			time.Sleep(5 * time.Millisecond)
+		} else {
+			time.Sleep(time.Millisecond)
+		}
+		mu.Lock()
+		active--
+		mu.Unlock()
+	}
+
+	var in []string
+	for i := 0; i < 300; i++ {
+		in = append(in, fmt.Sprint(i))
+	}
+	var adjustments []int
+	opts := AdaptiveOptions{Max: 16, Window: 4, OnAdjust: func(n int) { adjustments = append(adjustments, n) }}
+	got := New(FromStrings(in)).ParMapAdaptive(opts, func(v interface{}) interface{} {
+		call()
+		return v
+	}).Collect().([]string)
+	if strings.Join(got, ",") != strings.Join(in, ",") {
+		t.Errorf("ParMapAdaptive got the items out of order: %v", got)
+	}
+
+	backedOff := false
+	for i := 1; i < len(adjustments); i++ {
+		backedOff = backedOff || adjustments[i] < adjustments[i-1]
+	}
+	if peak < 2 || peak > 16 || !backedOff {
+		t.Errorf("ParMapAdaptive peaked at %d concurrent calls with the adjustments %v, want a ramp up then a back off", peak, adjustments)
+	}
+
+	var n int32
+	New(FromStrings(in)).ParEachAdaptive(AdaptiveOptions{Max: 4}, func(interface{}) { atomic.AddInt32(&n, 1) })
+	if n != 300 {
+		t.Errorf("ParEachAdaptive called f %d times, want: 300", n)
+	}
+}
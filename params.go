@@ -0,0 +1,121 @@
+package iter
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Params are the named parameters of a Pipeline run, e.g. limits,
+// thresholds or patterns, so that one Pipeline definition can be reused
+// across jobs with different settings. See Bind and RunWith.
+//
+// The accessors return the given default for a missing parameter and
+// panic for a malformed one, which makes RunConcurrent fail.
+type Params map[string]string
+
+// ParamsFromEnv returns the environment variables starting with prefix
+// as Params, named after the rest of the variable name in lower case,
+// e.g. JOB_LIMIT=10 gives the parameter "limit" for the prefix "JOB_".
+func ParamsFromEnv(prefix string) Params {
+	ps := Params{}
+	for _, kv := range os.Environ() {
+		if i := strings.IndexByte(kv, '='); i > len(prefix) && strings.HasPrefix(kv, prefix) {
+			ps[strings.ToLower(kv[len(prefix):i])] = kv[i+1:]
+		}
+	}
+	return ps
+}
+
+// ParamsFromFlags returns every flag of fs as Params, with either the
+// value it has been set to or its default value. fs must be parsed.
+func ParamsFromFlags(fs *flag.FlagSet) Params {
+	ps := Params{}
+	fs.VisitAll(func(f *flag.Flag) {
+		ps[f.Name] = f.Value.String()
+	})
+	return ps
+}
+
+// Merge returns new Params with the parameters of ps overridden by the
+// ones of others, in order, e.g. defaults.Merge(env, flags).
+func (ps Params) Merge(others ...Params) Params {
+	out := Params{}
+	for _, p := range append([]Params{ps}, others...) {
+		for k, v := range p {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// String returns the parameter name, def if missing.
+func (ps Params) String(name, def string) string {
+	if v, ok := ps[name]; ok {
+		return v
+	}
+	return def
+}
+
+// Int returns the parameter name as an int, def if missing.
+func (ps Params) Int(name string, def int) int {
+	v, ok := ps[name]
+	if !ok {
+		return def
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		panic(fmt.Sprintf("iter: parameter %q: %v", name, err))
+	}
+	return i
+}
+
+// Float returns the parameter name as a float64, def if missing.
+func (ps Params) Float(name string, def float64) float64 {
+	v, ok := ps[name]
+	if !ok {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		panic(fmt.Sprintf("iter: parameter %q: %v", name, err))
+	}
+	return f
+}
+
+// Regexp returns the parameter name compiled as a regular expression,
+// def compiled if missing.
+func (ps Params) Regexp(name, def string) *regexp.Regexp {
+	re, err := regexp.Compile(ps.String(name, def))
+	if err != nil {
+		panic(fmt.Sprintf("iter: parameter %q: %v", name, err))
+	}
+	return re
+}
+
+// Bind appends the stages built by f out of the Params of every run,
+// see RunWith. f is called once per run, before any item is processed.
+//
+// Example:
+//   p := NewPipeline().Bind(func(ps Params) *Pipeline {
+//      re := ps.Regexp("pattern", ".")
+//      return NewPipeline().Filter(func(v interface{}) bool {
+//         return re.MatchString(v.(string))
+//      })
+//   })
+//   p.RunWith(src, ParamsFromEnv("JOB_"))
+func (p *Pipeline) Bind(f func(Params) *Pipeline) *Pipeline {
+	return p.thenNested("Bind", sizeUnknown, func(ps Params, compose composer) step {
+		var out func(interface{})
+		push := compose(f(ps).stages, func(v interface{}) {
+			out(v)
+		}, ps)
+		return func(v interface{}, emit func(interface{})) {
+			out = emit
+			push(v)
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package iter
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParams(t *testing.T) {
+	t.Setenv("GOITER_TEST_PATTERN", "^a")
+	t.Setenv("GOITER_TEST_LIMIT", "1")
+
+	fs := flag.NewFlagSet("job", flag.ContinueOnError)
+	fs.Int("limit", 5, "")
+	fs.String("suffix", "!", "")
+	if err := fs.Parse([]string{"-limit", "2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewPipeline().Bind(func(ps Params) *Pipeline {
+		re, limit, suffix := ps.Regexp("pattern", "."), ps.Int("limit", 10), ps.String("suffix", "")
+		n := 0
+		return NewPipeline().
+			Filter(func(v interface{}) bool {
+				n++
+				return re.MatchString(v.(string)) && n <= limit
+			}).
+			Map(func(v interface{}) interface{} { return v.(string) + suffix })
+	})
+	src := []string{"ab", "b", "ac", "ad"}
+
+	for _, tc := range []struct {
+		ps   Params
+		want string
+	}{
+		{nil, "[ab b ac ad]"},
+		{ParamsFromEnv("GOITER_TEST_"), "[ab]"},
+		{ParamsFromEnv("GOITER_TEST_").Merge(ParamsFromFlags(fs)), "[ab!]"},
+		{Params{"limit": "3", "pattern": "c|d"}, "[ac]"},
+	} {
+		if got := p.RunWith(FromStrings(src), tc.ps).Collect(); fmt.Sprint(got) != tc.want {
+			t.Errorf("RunWith(%v) got: %v, want: %s", tc.ps, got, tc.want)
+		}
+	}
+
+	err := p.RunConcurrent(FromStrings(src), func(interface{}) error { return nil },
+		ConcurrentOptions{Params: Params{"limit": "many"}})
+	if err == nil || !strings.Contains(err.Error(), `parameter "limit"`) {
+		t.Errorf("RunConcurrent with a malformed parameter got error: %v", err)
+	}
+}
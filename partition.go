@@ -0,0 +1,211 @@
+package iter
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+)
+
+// SplitRandom distributes the items of the Iterable into two new
+// Iterators: every item goes to the first one with the probability of
+// fraction and to the second one otherwise, e.g. a train/test split.
+//
+// The randomness comes from rng so that a split can be reproduced by
+// seeding rng the same way. If rng is nil, the global source of the
+// math/rand package is used, unless WithSeed is in effect, so the split
+// differs from one run to the next, see WithSeed.
+//
+// Example:
+//   it := New(FromStrings(records))
+//   train, test := it.SplitRandom(0.8, rand.New(rand.NewSource(42)))
+func (it *Iter) SplitRandom(fraction float64, rng *rand.Rand) (*Iter, *Iter) {
+	picked, rest := it.impl.newItem(), it.impl.newItem()
+	var read, npicked int
+	for {
+		elm, more := it.impl.next()
+		if !more {
+			break
+		}
+		read++
+		if randFloat64(rng) < fraction {
+			picked.Add(elm)
+			npicked++
+		} else {
+			rest.Add(elm)
+		}
+	}
+	return it.impl.split("SplitRandom", read, []Iterable{picked, rest}, []int{npicked, read - npicked})
+}
+
+// Partition splits the items of the Iterable into two new Iterators in
+// one pass: the first one contains the items for which the predicate
+// returns true, the second one the others, both in the original order.
+//
+// Example:
+//   it := New(FromStrings([]string{"a", "1", "b"}))
+//   letters, digits := it.Partition(func(v interface{}) bool {
+//      return unicode.IsLetter(rune(v.(string)[0]))
+//   })
+//   produces letters contains []string{"a", "b"} and digits []string{"1"}
+func (it *Iter) Partition(f FilterFunc) (*Iter, *Iter) {
+	matched, rest := it.impl.newItem(), it.impl.newItem()
+	var read, nmatched int
+	for {
+		elm, more := it.impl.next()
+		if !more {
+			break
+		}
+		read++
+		if f(elm) {
+			matched.Add(elm)
+			nmatched++
+		} else {
+			rest.Add(elm)
+		}
+	}
+	return it.impl.split("Partition", read, []Iterable{matched, rest}, []int{nmatched, read - nmatched})
+}
+
+// Fold is one round of a k-fold partitioning, see KFold.
+type Fold struct {
+	Train *Iter
+	Test  *Iter
+}
+
+// KFold partitions the items of the Iterable into k folds for a k-fold
+// cross validation. The item with the (0-based) index i belongs to the
+// test set of the fold i%k and to the train set of every other fold, so
+// the partitioning is reproducible as long as the item order is stable.
+//
+// KFold reads the Iterable entirely and panics if k is less than 2.
+//
+// Example:
+//   it := New(FromStrings([]string{"a", "b", "c"}))
+//   folds := it.KFold(3)
+//   folds[1].Test contains []string{"b"}
+//   folds[1].Train contains []string{"a", "c"}
+func (it *Iter) KFold(k int) []*Fold {
+	if k < 2 {
+		panic(fmt.Sprintf("iter: KFold needs at least 2 folds, got %d", k))
+	}
+
+	items := it.impl.slice()
+	folds := make([]*Fold, k)
+	for f := range folds {
+		train, test := it.impl.newItem(), it.impl.newItem()
+		var ntest int
+		for i, v := range items {
+			if i%k == f {
+				test.Add(v)
+				ntest++
+			} else {
+				train.Add(v)
+			}
+		}
+		n := len(items)
+		trainIt, testIt := it.impl.split("KFold", n, []Iterable{train, test}, []int{n - ntest, ntest})
+		folds[f] = &Fold{trainIt, testIt}
+	}
+	return folds
+}
+
+// ShardBy routes every item of the Iterable into one of n new Iterators
+// by hashing the key of the item, so items sharing the same key always
+// end up in the same shard, e.g. for partition-parallel processing.
+//
+// The hash is stable across runs for keys of string, integer and bool
+// types. Keys of other types are hashed through their %#v format, hence
+// the format must identify the key.
+//
+// ShardBy panics if n is less than 1.
+//
+// Example:
+//   it := New(FromStrings([]string{"apple", "avocado", "banana"}))
+//   shards := it.ShardBy(4, func(v interface{}) interface{} {
+//      return v.(string)[:1]
+//   })
+//   "apple" and "avocado" are in the same shard.
+func (it *Iter) ShardBy(n int, key func(interface{}) interface{}) []*Iter {
+	if n < 1 {
+		panic(fmt.Sprintf("iter: ShardBy needs at least 1 shard, got %d", n))
+	}
+
+	shards, counts := make([]Iterable, n), make([]int, n)
+	for i := range shards {
+		shards[i] = it.impl.newItem()
+	}
+	var read int
+	for {
+		elm, more := it.impl.next()
+		if !more {
+			break
+		}
+		read++
+		i := hashKey(key(elm)) % uint64(n)
+		shards[i].Add(elm)
+		counts[i]++
+	}
+	return it.impl.splitN("ShardBy", read, shards, counts)
+}
+
+// SplitRoundRobin distributes the items of the Iterable evenly into n new
+// Iterators regardless of the items: the (0-based) i'th item goes to the
+// Iterator i%n, e.g. for balancing the load across n workers.
+//
+// SplitRoundRobin panics if n is less than 1.
+//
+// Example:
+//   it := New(FromStrings([]string{"a", "b", "c"}))
+//   its := it.SplitRoundRobin(2)
+//   its[0] contains []string{"a", "c"} and its[1] contains []string{"b"}
+func (it *Iter) SplitRoundRobin(n int) []*Iter {
+	if n < 1 {
+		panic(fmt.Sprintf("iter: SplitRoundRobin needs at least 1 output, got %d", n))
+	}
+
+	outs, counts := make([]Iterable, n), make([]int, n)
+	for i := range outs {
+		outs[i] = it.impl.newItem()
+	}
+	var read int
+	for ; ; read++ {
+		elm, more := it.impl.next()
+		if !more {
+			break
+		}
+		outs[read%n].Add(elm)
+		counts[read%n]++
+	}
+	return it.impl.splitN("SplitRoundRobin", read, outs, counts)
+}
+
+// split is like splitN for the stages producing two Iterators.
+func (it *iter) split(stage string, read int, items []Iterable, emitted []int) (*Iter, *Iter) {
+	its := it.splitN(stage, read, items, emitted)
+	return its[0], its[1]
+}
+
+// splitN derives an Iterator from every Iterable a stage has distributed
+// the items it has read into, emitted[i] being the number of items of
+// items[i].
+func (it *iter) splitN(stage string, read int, items []Iterable, emitted []int) []*Iter {
+	its := make([]*Iter, len(items))
+	for i, item := range items {
+		its[i] = newFromImpl(it.derive(stage, item, read, emitted[i]))
+	}
+	return its
+}
+
+// hashKey hashes a key with FNV-1a.
+func hashKey(k interface{}) uint64 {
+	h := fnv.New64a()
+	switch v := k.(type) {
+	case string:
+		h.Write([]byte(v))
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, bool:
+		fmt.Fprintf(h, "%d", v)
+	default:
+		fmt.Fprintf(h, "%#v", v)
+	}
+	return h.Sum64()
+}
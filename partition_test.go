@@ -0,0 +1,104 @@
+package iter
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestSplitRandom(t *testing.T) {
+	var s []string
+	for i := 0; i < 100; i++ {
+		s = append(s, fmt.Sprint(i))
+	}
+
+	split := func() ([]string, []string) {
+		a, b := New(FromStrings(s)).SplitRandom(0.7, rand.New(rand.NewSource(1)))
+		return a.Collect().([]string), b.Collect().([]string)
+	}
+
+	a1, b1 := split()
+	if len(a1)+len(b1) != len(s) {
+		t.Fatalf("SplitRandom lost items, got %d + %d, want %d", len(a1), len(b1), len(s))
+	}
+	if len(a1) < 50 || len(a1) > 90 {
+		t.Errorf("SplitRandom(0.7) picked %d out of %d items", len(a1), len(s))
+	}
+
+	a2, b2 := split()
+	if fmt.Sprint(a1, b1) != fmt.Sprint(a2, b2) {
+		t.Error("SplitRandom with the same seed is not reproducible")
+	}
+}
+
+func TestPartition(t *testing.T) {
+	var calls int
+	yes, no := New(FromStrings([]string{"a", "1", "b", "2", "c"})).Partition(func(v interface{}) bool {
+		calls++
+		return v.(string) >= "a"
+	})
+	if got := fmt.Sprint(yes.Collect(), no.Collect()); got != "[a b c] [1 2]" {
+		t.Errorf("Partition got: %s, want: [a b c] [1 2]", got)
+	}
+	if calls != 5 {
+		t.Errorf("Partition called the predicate %d times, want: 5", calls)
+	}
+}
+
+func TestKFold(t *testing.T) {
+	folds := New(FromStrings([]string{"a", "b", "c", "d", "e"})).KFold(2)
+	want := []struct{ train, test string }{
+		{"[b d]", "[a c e]"},
+		{"[a c e]", "[b d]"},
+	}
+
+	if len(folds) != len(want) {
+		t.Fatalf("KFold(2) got %d folds", len(folds))
+	}
+	for i, f := range folds {
+		train, test := fmt.Sprint(f.Train.Collect()), fmt.Sprint(f.Test.Collect())
+		if train != want[i].train || test != want[i].test {
+			t.Errorf("fold %d got train: %s test: %s, want train: %s test: %s",
+				i, train, test, want[i].train, want[i].test)
+		}
+	}
+}
+
+func TestShardBy(t *testing.T) {
+	s := []string{"apple", "avocado", "banana", "blueberry", "cherry", "apricot"}
+	shards := New(FromStrings(s)).ShardBy(3, func(v interface{}) interface{} {
+		return v.(string)[:1]
+	})
+
+	if len(shards) != 3 {
+		t.Fatalf("ShardBy(3) got %d shards", len(shards))
+	}
+	seen := map[string]int{}
+	total := 0
+	for i, shard := range shards {
+		for _, v := range shard.Collect().([]string) {
+			total++
+			if j, ok := seen[v[:1]]; ok && j != i {
+				t.Errorf("key %q is found in both shard %d and %d", v[:1], j, i)
+			}
+			seen[v[:1]] = i
+		}
+	}
+	if total != len(s) {
+		t.Errorf("ShardBy lost items, got %d, want %d", total, len(s))
+	}
+}
+
+func TestSplitRoundRobin(t *testing.T) {
+	its := New(FromStrings([]string{"a", "b", "c", "d", "e"})).SplitRoundRobin(3)
+	want := []string{"[a d]", "[b e]", "[c]"}
+
+	if len(its) != len(want) {
+		t.Fatalf("SplitRoundRobin(3) got %d outputs", len(its))
+	}
+	for i, it := range its {
+		if got := fmt.Sprint(it.Collect()); got != want[i] {
+			t.Errorf("output %d got: %s, want: %s", i, got, want[i])
+		}
+	}
+}
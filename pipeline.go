@@ -0,0 +1,508 @@
+package iter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Pipeline is a lazy chain of stages.
+//
+// Unlike the adapters of Iter, which materialize a new Iterable for every
+// step, the stages of a Pipeline are closures applied one item at a time:
+// nothing is read from the source until a terminal operation (Each, Count,
+// Collect, ...) traverses the Iterator returned by Run, and no intermediate
+// Iterable is ever created.
+//
+// A Pipeline is immutable, every stage API returns a new Pipeline, so one
+// definition can be extended in several ways and run many times.
+//
+// Example:
+//   p := NewPipeline().
+//      Filter(func(v interface{}) bool { return strings.HasPrefix(v.(string), "ab") }).
+//      Map(func(v interface{}) interface{} { return strings.ToUpper(v.(string)) })
+//   out := p.Run(FromStrings([]string{"abc", "bcd", "abd"})).Collect()
+//   out => []string{"ABC", "ABD"}
+type Pipeline struct {
+	stages []stage
+}
+
+// stage is a named step of a Pipeline.
+type stage struct {
+	name   string
+	sizing sizing
+	// build creates the step for a run, so that a stateful stage
+	// (e.g. the index of Every) starts over on every run, given the
+	// Params of the run.
+	build func(Params) step
+	// hint, if not nil, tells the planner how the stage behaves.
+	hint *Hint
+	// nest, if not nil, is the build of a stage composing nested stages,
+	// e.g. Bind, taking the composer of the run as well, so that
+	// RunDebug can reach the nested stages.
+	nest func(Params, composer) step
+}
+
+// composer chains the steps of stages, see compose.
+type composer func(stages []stage, sink func(interface{}), ps Params) func(interface{})
+
+// step processes an item and passes whatever it produces, if any,
+// to emit.
+type step func(v interface{}, emit func(interface{}))
+
+// NewPipeline creates an empty Pipeline, which passes the items through.
+func NewPipeline() *Pipeline {
+	return &Pipeline{}
+}
+
+// sizing tells how a stage changes the number of items, see SizeHint.
+type sizing int
+
+const (
+	// sizeUnknown is for stages which may emit any number of items.
+	sizeUnknown sizing = iota
+	// sizePreserved is for stages which emit one item per item.
+	sizePreserved
+	// sizeShrunk is for stages which emit at most one item per item.
+	sizeShrunk
+)
+
+// sizingOf tells how stages change the number of items all together.
+func sizingOf(stages []stage) sizing {
+	s := sizePreserved
+	for _, st := range stages {
+		switch st.sizing {
+		case sizeUnknown:
+			return sizeUnknown
+		case sizeShrunk:
+			s = sizeShrunk
+		}
+	}
+	return s
+}
+
+// then returns a new Pipeline with s appended.
+func (p *Pipeline) then(name string, sz sizing, build func(Params) step) *Pipeline {
+	stages := make([]stage, len(p.stages), len(p.stages)+1)
+	copy(stages, p.stages)
+	return &Pipeline{append(stages, stage{name: name, sizing: sz, build: build})}
+}
+
+// thenNested is like then for a stage composing nested stages by the
+// composer it's given, see stage.
+func (p *Pipeline) thenNested(name string, sz sizing, nest func(Params, composer) step) *Pipeline {
+	np := p.then(name, sz, func(ps Params) step {
+		return nest(ps, compose)
+	})
+	np.stages[len(np.stages)-1].nest = nest
+	return np
+}
+
+// Filter keeps the items for which the predicate returns true.
+func (p *Pipeline) Filter(f FilterFunc) *Pipeline {
+	return p.then("Filter", sizeShrunk, func(Params) step {
+		return func(v interface{}, emit func(interface{})) {
+			if f(v) {
+				emit(v)
+			}
+		}
+	})
+}
+
+// Map applies f against every item.
+func (p *Pipeline) Map(f MapFunc) *Pipeline {
+	return p.then("Map", sizePreserved, func(Params) step {
+		return func(v interface{}, emit func(interface{})) {
+			emit(f(v))
+		}
+	})
+}
+
+// Every applies f with a pair of (index, item) for every item, where
+// index counts the items reaching this stage.
+func (p *Pipeline) Every(f EveryFunc) *Pipeline {
+	return p.then("Every", sizePreserved, func(Params) step {
+		i := 0
+		return func(v interface{}, emit func(interface{})) {
+			emit(f(i, v))
+			i++
+		}
+	})
+}
+
+// Or replaces every item for which the predicate returns false with this.
+func (p *Pipeline) Or(f FilterFunc, this interface{}) *Pipeline {
+	return p.then("Or", sizePreserved, func(Params) step {
+		return func(v interface{}, emit func(interface{})) {
+			if f(v) {
+				emit(v)
+			} else {
+				emit(this)
+			}
+		}
+	})
+}
+
+// Then returns a new Pipeline with the stages of other appended.
+func (p *Pipeline) Then(other *Pipeline) *Pipeline {
+	stages := make([]stage, 0, len(p.stages)+len(other.stages))
+	stages = append(stages, p.stages...)
+	return &Pipeline{append(stages, other.stages...)}
+}
+
+// When appends the stages of other when cond is true, otherwise the
+// Pipeline is returned as is, e.g. for feature-flagged processing:
+//   p.When(cfg.Redact, NewPipeline().Map(redact))
+func (p *Pipeline) When(cond bool, other *Pipeline) *Pipeline {
+	if !cond {
+		return p
+	}
+	return p.Then(other)
+}
+
+// Unless is the opposite of When, it appends the stages of other
+// when cond is false.
+func (p *Pipeline) Unless(cond bool, other *Pipeline) *Pipeline {
+	return p.When(!cond, other)
+}
+
+// WhenFunc applies the stages of other only to the items for which the
+// predicate returns true, the other items bypass them. The stages keep
+// their state (e.g. the index of Every) across the items sent to them.
+func (p *Pipeline) WhenFunc(pred FilterFunc, other *Pipeline) *Pipeline {
+	return p.thenNested("When", sizingOf(other.stages), func(ps Params, compose composer) step {
+		var out func(interface{})
+		push := compose(other.stages, func(v interface{}) {
+			out(v)
+		}, ps)
+		return func(v interface{}, emit func(interface{})) {
+			if !pred(v) {
+				emit(v)
+				return
+			}
+			out = emit
+			push(v)
+		}
+	})
+}
+
+// Run returns an Iterator which lazily pulls the items of src through
+// the stages of the Pipeline.
+//
+// The returned Iterable creates new Iterables by src's New API. It is a
+// Rewinder only if src is a Rewinder, its Rewind rewinds src and restarts
+// the stages, so that a one-shot src stays one-shot, see Consumed. It is also a FromIter, Collect materializes whatever left into
+// a new Iterable created by src's New API.
+func (p *Pipeline) Run(src Iterable) *Iter {
+	return p.RunWith(src, nil)
+}
+
+// RunWith is like Run, the stages added by Bind are built from ps.
+func (p *Pipeline) RunWith(src Iterable, ps Params) *Iter {
+	return New(newLazy(src, p.stages, ps))
+}
+
+// compose chains the steps of stages for a run with the Params ps,
+// sink receives the items coming out of the last stage.
+func compose(stages []stage, sink func(interface{}), ps Params) func(interface{}) {
+	emit := sink
+	for i := len(stages) - 1; i >= 0; i-- {
+		s, next := stages[i].build(ps), emit
+		emit = func(v interface{}) {
+			s(v, next)
+		}
+	}
+	return emit
+}
+
+// lazy is the Iterable behind Pipeline.Run.
+type lazy struct {
+	src    Iterable
+	stages []stage
+	params Params
+	push   func(interface{})
+	// buf queues the items produced out of one source item.
+	buf []interface{}
+	// err is the error of the New API of src, see To.
+	err error
+}
+
+// newLazy creates the lazy run of stages over src, which is a Rewinder
+// only if src is one.
+func newLazy(src Iterable, stages []stage, ps Params) Iterable {
+	l := &lazy{src: src, stages: stages, params: ps}
+	l.start()
+	if _, ok := src.(Rewinder); ok {
+		return rewindableLazy{l}
+	}
+	return l
+}
+
+func (l *lazy) start() {
+	l.buf = l.buf[:0]
+	l.push = compose(l.stages, func(v interface{}) {
+		l.buf = append(l.buf, v)
+	}, l.params)
+}
+
+func (l *lazy) New() (Iterable, error) {
+	return l.src.New()
+}
+
+func (*lazy) Add(interface{}) {
+	panic("iter: Add is not supported by a Pipeline Iterable")
+}
+
+func (l *lazy) Next() (interface{}, bool) {
+	for len(l.buf) == 0 {
+		v, more := l.src.Next()
+		if !more {
+			return nil, false
+		}
+		l.push(v)
+	}
+	v := l.buf[0]
+	l.buf = l.buf[1:]
+	return v, true
+}
+
+// rewindableLazy is a lazy over a Rewinder.
+type rewindableLazy struct {
+	*lazy
+}
+
+// Rewind rewinds src and restarts the stages.
+func (l rewindableLazy) Rewind() {
+	l.src.(Rewinder).Rewind()
+	l.start()
+}
+
+// Err returns the error of src, if any, or the one of its New API met
+// by To.
+func (l *lazy) Err() error {
+	if err := errOf(l.src); err != nil {
+		return err
+	}
+	return l.err
+}
+
+// SizeHint derives the bounds from the size of src and the stages.
+func (l *lazy) SizeHint() (int, int) {
+	lower, upper := sizeHintOf(l.src)
+	switch sizingOf(l.stages) {
+	case sizePreserved:
+		return lower, upper
+	case sizeShrunk:
+		return 0, upper
+	}
+	return 0, -1
+}
+
+// To materializes whatever left into a new Iterable created by src's
+// New API, an in-memory one if it fails, see Err.
+func (l *lazy) To() interface{} {
+	newitem, err := newOf(l.src)
+	if err != nil && l.err == nil {
+		l.err = err
+	}
+	for {
+		v, more := l.Next()
+		if !more {
+			break
+		}
+		newitem.Add(v)
+	}
+	return newitem.(FromIter).To()
+}
+
+// Route sends every item of the Iterable through the Pipeline selected by
+// the key of the item and returns a new Iterator merging the outputs of
+// all the Pipelines, in the order of the source items.
+//
+// An item whose key isn't in routes goes through fallback, or is dropped
+// if fallback is nil. Every Pipeline keeps its state (e.g. the index of
+// Every) across the items routed to it.
+//
+// Example:
+//   it := New(FromStrings([]string{"a", "1", "b"}))
+//   newit := it.Route(
+//      func(v interface{}) string {
+//         if _, err := strconv.Atoi(v.(string)); err == nil {
+//            return "number"
+//         }
+//         return "text"
+//      },
+//      map[string]*Pipeline{"text": NewPipeline().Map(func(v interface{}) interface{} {
+//         return strings.ToUpper(v.(string))
+//      })},
+//      NewPipeline().Map(func(v interface{}) interface{} { return "#" + v.(string) }))
+//   produces a newit contains []string{"A", "#1", "B"}
+func (it *Iter) Route(selector func(interface{}) string, routes map[string]*Pipeline, fallback *Pipeline) *Iter {
+	newitem := it.impl.newItem()
+	var emitted int
+	sink := func(v interface{}) {
+		newitem.Add(v)
+		emitted++
+	}
+
+	pushes := make(map[string]func(interface{}), len(routes))
+	for key, p := range routes {
+		pushes[key] = compose(p.stages, sink, nil)
+	}
+	var other func(interface{})
+	if fallback != nil {
+		other = compose(fallback.stages, sink, nil)
+	}
+
+	var read int
+	for {
+		elm, more := it.impl.next()
+		if !more {
+			break
+		}
+		read++
+		if push, ok := pushes[selector(elm)]; ok {
+			push(elm)
+		} else if other != nil {
+			other(elm)
+		}
+	}
+	return newFromImpl(it.impl.derive("Route", newitem, read, emitted))
+}
+
+// When returns a new Iterator with the stages of p applied when cond
+// is true, otherwise the Iterator itself is returned. The new Iterator
+// has the strict mode, the RewindPolicy and the error of this one.
+func (it *Iter) When(cond bool, p *Pipeline) *Iter {
+	if !cond {
+		return it
+	}
+	return newFromImpl(it.impl.wrap(newLazy(it.impl.item, p.stages, nil)))
+}
+
+// WhenFunc returns a new Iterator where the stages of p are only
+// applied to the items for which the predicate returns true, the other
+// items pass through unchanged. Same as Pipeline.Run, the returned
+// Iterator is lazy. Same as When, it keeps the settings of this Iterator.
+func (it *Iter) WhenFunc(pred FilterFunc, p *Pipeline) *Iter {
+	return it.When(true, NewPipeline().WhenFunc(pred, p))
+}
+
+// ConcurrentOptions configures RunConcurrent.
+type ConcurrentOptions struct {
+	// Context cancels the run, nil means context.Background().
+	Context context.Context
+	// Buffer is the buffer size of every channel between two
+	// goroutines, unless overridden by Buffers.
+	Buffer int
+	// Buffers overrides the buffer size per channel: Buffers[i] is the
+	// channel feeding the stage i, Buffers[len(stages)] feeds the sink.
+	Buffers []int
+	// Params are the parameters of the run, see Bind.
+	Params Params
+}
+
+// RunConcurrent runs the Pipeline over src as a classic staged pipeline:
+// the source and every stage run in their own goroutine, connected by
+// bounded channels, so that I/O-bound stages overlap. sink receives the
+// items coming out of the last stage, in order, on the calling goroutine.
+//
+// The run stops at the first error, which is returned: a non-nil error
+// returned by sink, a panic of the source or a stage, or the cancellation
+// of the Context. All the goroutines have exited when RunConcurrent
+// returns.
+//
+// Example:
+//   err := p.RunConcurrent(src, func(v interface{}) error {
+//      return store(v)
+//   }, ConcurrentOptions{Context: ctx, Buffer: 16})
+func (p *Pipeline) RunConcurrent(src Iterable, sink func(interface{}) error, opts ConcurrentOptions) error {
+	parent := opts.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	var once sync.Once
+	var firstErr error
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+	bufferOf := func(i int) int {
+		if i < len(opts.Buffers) {
+			return opts.Buffers[i]
+		}
+		return opts.Buffer
+	}
+	send := func(out chan<- interface{}, v interface{}) {
+		select {
+		case out <- v:
+		case <-ctx.Done():
+		}
+	}
+
+	var wg sync.WaitGroup
+	in := make(chan interface{}, bufferOf(0))
+	wg.Add(1)
+	go func(out chan<- interface{}) {
+		defer wg.Done()
+		defer close(out)
+		defer recoverInto(fail, "source")
+		for ctx.Err() == nil {
+			v, more := src.Next()
+			if !more {
+				return
+			}
+			send(out, v)
+		}
+	}(in)
+
+	for i, st := range p.stages {
+		out := make(chan interface{}, bufferOf(i+1))
+		wg.Add(1)
+		go func(i int, st stage, in <-chan interface{}, out chan<- interface{}) {
+			defer wg.Done()
+			defer close(out)
+			defer recoverInto(fail, fmt.Sprintf("stage %d (%s)", i, st.name))
+			s := st.build(opts.Params)
+			emit := func(v interface{}) {
+				send(out, v)
+			}
+			for v := range in {
+				if ctx.Err() != nil {
+					return
+				}
+				s(v, emit)
+			}
+		}(i, st, in, out)
+		in = out
+	}
+
+	for v := range in {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := sink(v); err != nil {
+			fail(err)
+			break
+		}
+	}
+	cancel()
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return parent.Err()
+}
+
+// recoverInto turns a panic of the goroutine running who into an error.
+func recoverInto(fail func(error), who string) {
+	if r := recover(); r != nil {
+		fail(fmt.Errorf("iter: %s panicked: %v", who, r))
+	}
+}
@@ -0,0 +1,235 @@
+package iter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPipeline(t *testing.T) {
+	var seen []string
+	p := NewPipeline().
+		Filter(func(v interface{}) bool {
+			seen = append(seen, v.(string))
+			return strings.HasPrefix(v.(string), "ab")
+		}).
+		Or(func(v interface{}) bool { return v.(string) != "abd" }, "abz").
+		Map(func(v interface{}) interface{} { return strings.ToUpper(v.(string)) }).
+		Every(func(i int, v interface{}) interface{} { return fmt.Sprintf("%d:%s", i, v) })
+
+	it := p.Run(FromStrings([]string{"abc", "bcd", "abd"}))
+	if len(seen) != 0 {
+		t.Fatalf("Pipeline read items before a terminal runs: %v", seen)
+	}
+
+	if v, _ := it.impl.item.Next(); v != "0:ABC" || len(seen) != 1 {
+		t.Errorf("Pipeline got first item: %v after reading %v, want: 0:ABC after reading [abc]", v, seen)
+	}
+	it.impl.item.(Rewinder).Rewind()
+
+	if n := it.Count(); n != 2 {
+		t.Errorf("Pipeline Count got: %d, want: 2", n)
+	}
+	if got := fmt.Sprint(it.Collect()); got != "[0:ABC 1:ABZ]" {
+		t.Errorf("Pipeline Collect got: %s, want: [0:ABC 1:ABZ]", got)
+	}
+
+	// The Pipeline is reusable and immutable.
+	short := p.Run(FromStrings([]string{"abq"}))
+	longer := p.Map(func(v interface{}) interface{} { return v.(string) + "!" }).Run(FromStrings([]string{"abq"}))
+	if got := fmt.Sprint(short.Collect(), longer.Collect()); got != "[0:ABQ] [0:ABQ!]" {
+		t.Errorf("Pipeline reused got: %s, want: [0:ABQ] [0:ABQ!]", got)
+	}
+}
+
+func TestRunOneShot(t *testing.T) {
+	p := NewPipeline().Map(func(v interface{}) interface{} { return v.(int) * 10 })
+
+	if p.Run(oneShot{FromInts([]int{1, 2, 3})}).Capabilities().Rewinder {
+		t.Error("Run over a one-shot source is a Rewinder")
+	}
+	if !p.Run(FromInts([]int{1, 2, 3})).Capabilities().Rewinder {
+		t.Error("Run over a Rewinder isn't a Rewinder")
+	}
+
+	// The outliers and Normalize take their one-pass path.
+	got := p.Run(oneShot{FromInts([]int{0, 5, 10})}).Normalize(MinMax).Collect()
+	if fmt.Sprint(got) != "[0 1 1]" {
+		t.Errorf("Normalize of a one-shot run got: %v, want: [0 1 1]", got)
+	}
+
+	it := p.Run(oneShot{FromInts([]int{1, 2, 3})}).Strict()
+	if n := it.Count(); n != 3 {
+		t.Errorf("Count got: %d, want: 3", n)
+	}
+	if !it.Consumed() {
+		t.Error("a one-shot run isn't Consumed after Count")
+	}
+	if it.Count(); !errors.Is(it.Err(), ErrConsumed) {
+		t.Errorf("Count of a consumed run got Err: %v, want: %v", it.Err(), ErrConsumed)
+	}
+}
+
+func BenchmarkPipeline(b *testing.B) {
+	s := make([]string, 1<<10)
+	for i := range s {
+		s[i] = fmt.Sprint(i)
+	}
+	keep := func(v interface{}) bool { return len(v.(string)) > 1 }
+	upper := func(v interface{}) interface{} { return v.(string) + "!" }
+
+	b.Run("eager", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			New(FromStrings(s)).Filter(keep).Map(upper).Map(upper).Count()
+		}
+	})
+	b.Run("lazy", func(b *testing.B) {
+		p := NewPipeline().Filter(keep).Map(upper).Map(upper)
+		for i := 0; i < b.N; i++ {
+			p.Run(FromStrings(s)).Count()
+		}
+	})
+}
+
+func TestRunConcurrent(t *testing.T) {
+	var s []string
+	for i := 0; i < 100; i++ {
+		s = append(s, fmt.Sprint(i))
+	}
+	p := NewPipeline().
+		Filter(func(v interface{}) bool { return len(v.(string)) == 2 }).
+		Map(func(v interface{}) interface{} { return v.(string) + "!" })
+
+	t.Run("ordered", func(t *testing.T) {
+		var got []string
+		err := p.RunConcurrent(FromStrings(s), func(v interface{}) error {
+			got = append(got, v.(string))
+			return nil
+		}, ConcurrentOptions{Buffer: 4, Buffers: []int{0}})
+		if err != nil || len(got) != 90 || got[0] != "10!" || got[89] != "99!" {
+			t.Errorf("RunConcurrent got %d items from %v to %v with error: %v", len(got), got[0], got[len(got)-1], err)
+		}
+	})
+
+	t.Run("sink-error", func(t *testing.T) {
+		stop := errors.New("stop")
+		n := 0
+		err := p.RunConcurrent(FromStrings(s), func(v interface{}) error {
+			n++
+			if n == 3 {
+				return stop
+			}
+			return nil
+		}, ConcurrentOptions{})
+		if err != stop || n != 3 {
+			t.Errorf("RunConcurrent got error: %v after %d items, want: %v after 3 items", err, n, stop)
+		}
+	})
+
+	t.Run("stage-panic", func(t *testing.T) {
+		boom := p.Map(func(v interface{}) interface{} {
+			if v.(string) == "50!" {
+				panic("boom")
+			}
+			return v
+		})
+		err := boom.RunConcurrent(FromStrings(s), func(interface{}) error { return nil }, ConcurrentOptions{Buffer: 2})
+		if err == nil || !strings.Contains(err.Error(), "stage 2 (Map) panicked: boom") {
+			t.Errorf("RunConcurrent got error: %v, want the panic of stage 2", err)
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		n := 0
+		err := p.RunConcurrent(FromStrings(s), func(interface{}) error {
+			n++
+			if n == 5 {
+				cancel()
+			}
+			return nil
+		}, ConcurrentOptions{Context: ctx})
+		if err != context.Canceled || n != 5 {
+			t.Errorf("RunConcurrent got error: %v after %d items, want: %v after 5 items", err, n, context.Canceled)
+		}
+	})
+}
+
+func TestRoute(t *testing.T) {
+	selector := func(v interface{}) string {
+		switch s := v.(string); {
+		case s >= "0" && s <= "9":
+			return "number"
+		case s == "-":
+			return "dash"
+		}
+		return "text"
+	}
+	routes := map[string]*Pipeline{
+		"text": NewPipeline().
+			Map(func(v interface{}) interface{} { return strings.ToUpper(v.(string)) }).
+			Every(func(i int, v interface{}) interface{} { return fmt.Sprintf("%s%d", v, i) }),
+		"dash": NewPipeline().Filter(func(interface{}) bool { return false }),
+	}
+	fallback := NewPipeline().Map(func(v interface{}) interface{} { return "#" + v.(string) })
+	in := []string{"a", "1", "-", "b", "2"}
+
+	got := New(FromStrings(in)).Route(selector, routes, fallback).Collect()
+	if fmt.Sprint(got) != "[A0 #1 B1 #2]" {
+		t.Errorf("Route got: %v, want: [A0 #1 B1 #2]", got)
+	}
+
+	got = New(FromStrings(in)).Route(selector, routes, nil).Collect()
+	if fmt.Sprint(got) != "[A0 B1]" {
+		t.Errorf("Route without fallback got: %v, want: [A0 B1]", got)
+	}
+}
+
+func TestWhen(t *testing.T) {
+	upper := NewPipeline().Map(func(v interface{}) interface{} { return strings.ToUpper(v.(string)) })
+	bang := NewPipeline().Map(func(v interface{}) interface{} { return v.(string) + "!" })
+	in := []string{"a", "b1", "c"}
+
+	tests := []struct {
+		desc string
+		p    *Pipeline
+		want string
+	}{
+		{"when-true", NewPipeline().When(true, upper).When(false, bang), "[A B1 C]"},
+		{"unless", NewPipeline().Unless(true, upper).Unless(false, bang), "[a! b1! c!]"},
+		{"when-func", NewPipeline().WhenFunc(func(v interface{}) bool { return len(v.(string)) == 1 },
+			upper.Every(func(i int, v interface{}) interface{} { return fmt.Sprint(v, i) })), "[A0 b1 C1]"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := fmt.Sprint(tc.p.Run(FromStrings(in)).Collect()); got != tc.want {
+				t.Errorf("%s got: %s, want: %s", tc.desc, got, tc.want)
+			}
+		})
+	}
+
+	it := New(FromStrings(in))
+	if it.When(false, upper) != it {
+		t.Error("Iter.When(false) doesn't return the Iterator itself")
+	}
+	if got := fmt.Sprint(it.When(true, bang).Collect()); got != "[a! b1! c!]" {
+		t.Errorf("Iter.When(true) got: %s, want: [a! b1! c!]", got)
+	}
+	odd := New(FromStrings(in)).WhenFunc(func(v interface{}) bool { return v.(string) != "c" }, bang)
+	if got := fmt.Sprint(odd.Collect()); got != "[a! b1! c]" {
+		t.Errorf("Iter.WhenFunc got: %s, want: [a! b1! c]", got)
+	}
+
+	// The settings of the Iterator are kept.
+	for _, newit := range []*Iter{
+		New(oneShot{FromStrings(in)}).WithRewind(ErrorIfConsuming).When(true, bang),
+		New(oneShot{FromStrings(in)}).WithRewind(ErrorIfConsuming).WhenFunc(func(interface{}) bool { return true }, bang),
+	} {
+		if n := newit.Count(); n != 0 || !errors.Is(newit.Err(), ErrNotRewinder) {
+			t.Errorf("Count under ErrorIfConsuming got: %d, %v, want: 0, %v", n, newit.Err(), ErrNotRewinder)
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package iter
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Hint tells the planner how a stage of a Pipeline behaves, see
+// Pipeline.Hint and Pipeline.Learn.
+type Hint struct {
+	// Selectivity is the ratio of the items emitted by the stage to
+	// the items it reads, e.g. 0.1 for a Filter keeping 10% of them.
+	Selectivity float64
+	// Cost is the average time the stage spends per item it reads.
+	Cost time.Duration
+}
+
+// String implements the Stringer interface for Hint.
+func (h Hint) String() string {
+	return fmt.Sprintf("selectivity %.2f, cost %v", h.Selectivity, h.Cost)
+}
+
+// Hint returns a new Pipeline whose last stage is annotated with h, for
+// Optimize. It returns the Pipeline as is if it has no stage.
+//
+// Example:
+//   p := NewPipeline().
+//      Filter(matchesRegexp).Hint(Hint{Selectivity: 0.5, Cost: 20 * time.Microsecond}).
+//      Filter(isRecent).Hint(Hint{Selectivity: 0.1, Cost: 50 * time.Nanosecond})
+func (p *Pipeline) Hint(h Hint) *Pipeline {
+	if len(p.stages) == 0 {
+		return p
+	}
+	stages := append([]stage(nil), p.stages...)
+	stages[len(stages)-1].hint = &h
+	return &Pipeline{stages}
+}
+
+// Learn runs the Pipeline over sample, e.g. the first items of a prior
+// run, and returns a new Pipeline whose stages are annotated with the
+// Hint measured for them, for Optimize. The sample is consumed. A stage
+// which reads no item of the sample is left without Hint.
+func (p *Pipeline) Learn(sample Iterable) *Pipeline {
+	n := len(p.stages)
+	read, emitted := make([]int, n), make([]int, n)
+	spent := make([]time.Duration, n)
+
+	push := func(interface{}) {}
+	for i := n - 1; i >= 0; i-- {
+		i, s, next := i, p.stages[i].build(nil), push
+		push = func(v interface{}) {
+			read[i]++
+			var downstream time.Duration
+			start := time.Now()
+			s(v, func(out interface{}) {
+				emitted[i]++
+				t := time.Now()
+				next(out)
+				downstream += time.Since(t)
+			})
+			spent[i] += time.Since(start) - downstream
+		}
+	}
+	for {
+		v, more := sample.Next()
+		if !more {
+			break
+		}
+		push(v)
+	}
+
+	stages := append([]stage(nil), p.stages...)
+	for i := range stages {
+		if read[i] > 0 {
+			stages[i].hint = &Hint{
+				Selectivity: float64(emitted[i]) / float64(read[i]),
+				Cost:        spent[i] / time.Duration(read[i]),
+			}
+		}
+	}
+	return &Pipeline{stages}
+}
+
+// Optimize returns a new Pipeline where every run of consecutive Filter
+// stages is reordered so that the cheapest and most selective ones go
+// first, by the ascending Cost / (1 - Selectivity) of their Hint. As
+// Filters don't change the items, their order doesn't change the output,
+// only how many items the expensive ones read. The Filters without Hint
+// keep their order after the hinted ones. The other stages don't move.
+//
+// Example:
+//   p = p.Learn(sample).Optimize()
+//   fmt.Print(p.Explain())
+func (p *Pipeline) Optimize() *Pipeline {
+	stages := append([]stage(nil), p.stages...)
+	for i := 0; i < len(stages); {
+		j := i
+		for j < len(stages) && stages[j].name == "Filter" {
+			j++
+		}
+		if j == i {
+			i++
+			continue
+		}
+		run := stages[i:j]
+		sort.SliceStable(run, func(a, b int) bool { return rank(run[a].hint) < rank(run[b].hint) })
+		i = j
+	}
+	return &Pipeline{stages}
+}
+
+// rank orders the Filters, the lower the earlier.
+func rank(h *Hint) float64 {
+	if h == nil || h.Selectivity >= 1 {
+		return math.Inf(1)
+	}
+	return float64(h.Cost) / (1 - h.Selectivity)
+}
+
+// Plan describes how a Pipeline runs, see Explain.
+type Plan struct {
+	// Input is the expected number of items read from the source.
+	Input Cardinality
+	// Stages are in the order they run.
+	Stages []StagePlan
+}
+
+// StagePlan describes a stage of a Pipeline.
+type StagePlan struct {
+	// Name is the name of the API producing the stage, e.g. "Filter",
+	// or the algorithm chosen for it, e.g. "HashJoin".
+	Name string
+	// Algorithm tells how the stage processes the items, e.g.
+	// "streaming" for a stage handling one item at a time.
+	Algorithm string
+	// Materialized tells whether the stage holds a whole Iterable in
+	// memory, e.g. the right side of a HashJoin.
+	Materialized bool
+	// Output is the expected number of items emitted by the stage.
+	Output Cardinality
+	// Hint is the Hint of the stage, if Hinted.
+	Hint   Hint
+	Hinted bool
+}
+
+// Cardinality is an expected number of items.
+type Cardinality struct {
+	// Lower and Upper are the bounds given by SizeHint, Upper is -1
+	// when unbounded or unknown.
+	Lower, Upper int
+	// Estimate is the number of items expected from the Hints, -1 if
+	// unknown.
+	Estimate float64
+}
+
+// String implements the Stringer interface for Cardinality, e.g.
+// "[0, 100] ~25" or "[0, ?]".
+func (c Cardinality) String() string {
+	upper := "?"
+	if c.Upper >= 0 {
+		upper = fmt.Sprint(c.Upper)
+	}
+	s := fmt.Sprintf("[%d, %s]", c.Lower, upper)
+	if c.Estimate >= 0 {
+		s += fmt.Sprintf(" ~%.0f", c.Estimate)
+	}
+	return s
+}
+
+// String implements the Stringer interface for Plan, one line for the
+// source then one per stage.
+func (pl *Plan) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "0. Source, lazy: %v items\n", pl.Input)
+	for i, st := range pl.Stages {
+		fmt.Fprintf(&b, "%d. %s, %s", i+1, st.Name, st.Algorithm)
+		if st.Materialized {
+			b.WriteString(", materialized")
+		}
+		fmt.Fprintf(&b, ": %v items", st.Output)
+		if st.Hinted {
+			fmt.Fprintf(&b, " (%v)", st.Hint)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Explain returns the Plan of the Pipeline, e.g. to check what Optimize
+// or Join has chosen, or to reason about the cost of a run before running
+// it on a large Iterable. The cardinalities are unknown, see ExplainFor.
+//
+// Every stage runs lazily, pulling one item at a time in order, whether
+// by Run or by RunConcurrent, which runs each stage on its own goroutine.
+func (p *Pipeline) Explain() *Plan {
+	return p.explain(-1, -1)
+}
+
+// ExplainFor is like Explain but derives the expected cardinalities from
+// the SizeHint of src, see SizeHinter, and from the Hints of the stages.
+// src isn't read.
+//
+// Example:
+//   fmt.Print(p.Learn(sample).Optimize().ExplainFor(src))
+//   0. Source, lazy: [1000, 1000] ~1000 items
+//   1. Filter, streaming: [0, 1000] ~100 items (selectivity 0.10, cost 50ns)
+//   2. Map, streaming: [0, 1000] ~100 items (selectivity 1.00, cost 2µs)
+func (p *Pipeline) ExplainFor(src Iterable) *Plan {
+	return p.explain(sizeHintOf(src))
+}
+
+func (p *Pipeline) explain(lower, upper int) *Plan {
+	if lower < 0 {
+		lower = 0
+	}
+	card := Cardinality{lower, upper, -1}
+	if upper >= 0 && lower == upper {
+		card.Estimate = float64(upper)
+	}
+	pl := &Plan{Input: card, Stages: make([]StagePlan, len(p.stages))}
+
+	for i, st := range p.stages {
+		switch st.sizing {
+		case sizeShrunk:
+			card.Lower = 0
+		case sizeUnknown:
+			card.Lower, card.Upper = 0, -1
+			if st.hint == nil {
+				card.Estimate = -1
+			}
+		}
+		sp := &pl.Stages[i]
+		sp.Name = st.name
+		sp.Algorithm, sp.Materialized = algorithmOf(st.name)
+		if st.hint != nil {
+			sp.Hint, sp.Hinted = *st.hint, true
+			if card.Estimate >= 0 {
+				card.Estimate *= st.hint.Selectivity
+			}
+		} else if st.sizing == sizeShrunk && card.Estimate >= 0 {
+			// At most the items read, without a Hint telling how many.
+			card.Estimate = -1
+		}
+		sp.Output = card
+	}
+	return pl
+}
+
+// algorithmOf describes how the stage called name processes the items.
+func algorithmOf(name string) (string, bool) {
+	switch name {
+	case "HashJoin":
+		return "hash join on the right side", true
+	case "MergeJoin":
+		return "merge join of the sorted sides", false
+	case "Bind":
+		return "built from the Params of every run", false
+	case "When":
+		return "streaming, conditional", false
+	}
+	return "streaming", false
+}
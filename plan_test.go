@@ -0,0 +1,102 @@
+package iter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOptimize(t *testing.T) {
+	var calls []string
+	filter := func(name string, keep func(int) bool) FilterFunc {
+		return func(v interface{}) bool {
+			calls = append(calls, name)
+			return keep(v.(int))
+		}
+	}
+	p := NewPipeline().
+		Map(func(v interface{}) interface{} { return v }).
+		Filter(filter("even", func(i int) bool { return i%2 == 0 })).Hint(Hint{Selectivity: 0.5, Cost: time.Microsecond}).
+		Filter(filter("any", func(int) bool { return true })).
+		Filter(filter("big", func(i int) bool { return i >= 8 })).Hint(Hint{Selectivity: 0.2, Cost: time.Microsecond})
+
+	var got []string
+	for _, st := range p.Optimize().Explain().Stages {
+		got = append(got, fmt.Sprintf("%s %.1f", st.Name, st.Hint.Selectivity))
+	}
+	if want := "[Map 0.0 Filter 0.2 Filter 0.5 Filter 0.0]"; fmt.Sprint(got) != want {
+		t.Errorf("Optimize got:\n%s, want:\n%s", got, want)
+	}
+
+	var out []interface{}
+	p.Optimize().Run(Range(0, 10, 1)).Each(func(v interface{}) { out = append(out, v) })
+	if fmt.Sprint(out) != "[8]" || len(calls) != 10+2+1 {
+		t.Errorf("optimized Pipeline got: %v with %d calls, want: [8] with 13 calls", out, len(calls))
+	}
+}
+
+func TestLearn(t *testing.T) {
+	p := NewPipeline().
+		Filter(func(v interface{}) bool { return v.(int)%4 == 0 }).
+		Map(func(v interface{}) interface{} { return v })
+	plan := p.Learn(Range(0, 100, 1)).Explain()
+	if st := plan.Stages[0]; !st.Hinted || st.Hint.Selectivity != 0.25 {
+		t.Errorf("Learn got Filter hint: %+v, want a selectivity of 0.25", st)
+	}
+	if st := plan.Stages[1]; !st.Hinted || st.Hint.Selectivity != 1 {
+		t.Errorf("Learn got Map hint: %+v, want a selectivity of 1", st)
+	}
+	if plan := p.Learn(Empty()).Explain(); plan.Stages[0].Hinted {
+		t.Errorf("Learn on an empty sample got: %v, want no hint", plan)
+	}
+}
+
+func TestPipelineJoin(t *testing.T) {
+	right := FromStrings([]string{"a2", "c2", "c3", "d2"})
+	opts := JoinOptions{
+		LeftKey:  func(v interface{}) interface{} { return v.(string)[:1] },
+		RightKey: func(v interface{}) interface{} { return v.(string)[:1] },
+		Project:  func(l, r interface{}) interface{} { return l.(string) + r.(string) },
+	}
+
+	for _, sorted := range []bool{false, true} {
+		opts.Sorted = sorted
+		p := NewPipeline().Join(right, opts)
+		var got []string
+		it := p.Run(FromStrings([]string{"a1", "b1", "c1", "c9"}))
+		for i := 0; i < 2; i++ {
+			got = got[:0]
+			it.Each(func(v interface{}) { got = append(got, v.(string)) })
+			if strings.Join(got, " ") != "a1a2 c1c2 c1c3 c9c2 c9c3" {
+				t.Errorf("%s run %d got: %v, want: [a1a2 c1c2 c1c3 c9c2 c9c3]", p.Explain().Stages[0].Name, i, got)
+			}
+		}
+	}
+	if name := NewPipeline().Join(right, opts).Explain().Stages[0].Name; name != "MergeJoin" {
+		t.Errorf("sorted Join got: %s, want: MergeJoin", name)
+	}
+}
+
+func TestExplainFor(t *testing.T) {
+	p := NewPipeline().
+		Map(func(v interface{}) interface{} { return v }).
+		Filter(func(interface{}) bool { return true }).Hint(Hint{Selectivity: 0.1}).
+		Filter(func(interface{}) bool { return true }).
+		Join(Empty(), JoinOptions{})
+
+	got := p.ExplainFor(Range(0, 1000, 1)).String()
+	want := `0. Source, lazy: [1000, 1000] ~1000 items
+1. Map, streaming: [1000, 1000] ~1000 items
+2. Filter, streaming: [0, 1000] ~100 items (selectivity 0.10, cost 0s)
+3. Filter, streaming: [0, 1000] items
+4. HashJoin, hash join on the right side, materialized: [0, ?] items
+`
+	if got != want {
+		t.Errorf("ExplainFor got:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := p.Explain().Stages[0].Output.String(); got != "[0, ?]" {
+		t.Errorf("Explain got Map output: %s, want: [0, ?]", got)
+	}
+}
@@ -0,0 +1,144 @@
+package iter
+
+import "fmt"
+
+// Origin tells where an item comes from.
+type Origin struct {
+	// Source names the source, e.g. a file name or a URL.
+	Source string
+	// Page is the page of a paged source, 0 otherwise.
+	Page int
+	// Record is the (1-based) position of the item in its source, or in
+	// its page, e.g. the line of a file of lines.
+	Record int
+}
+
+// String implements the Stringer interface for Origin, e.g.
+// "access.log:12" or "api page 3:12".
+func (o Origin) String() string {
+	if o.Page != 0 {
+		return fmt.Sprintf("%s page %d:%d", o.Source, o.Page, o.Record)
+	}
+	return fmt.Sprintf("%s:%d", o.Source, o.Record)
+}
+
+// Traced is an item tagged with its Origin, see Trace.
+type Traced struct {
+	Value  interface{}
+	Origin Origin
+}
+
+// String implements the Stringer interface for Traced, so that an error
+// formatting the item, e.g. a *MapError, tells where it comes from.
+func (t *Traced) String() string {
+	return fmt.Sprintf("%v at %s", t.Value, t.Origin)
+}
+
+// Trace turns provenance tracking on: it returns a new Iterator whose
+// items are *Traced, each one tagging an item of the Iterable with its
+// Origin in source, the Record being its position. A paged source may
+// create its own *Traced instead, with their Page set.
+//
+// The functions given to the adapters receive the *Traced, TraceMap,
+// TraceFilter and TraceConvert wrap the ones written for plain items so
+// that they keep the Origin along the chain. Untrace turns the tracking
+// off. The returned Iterator is lazy and starts a fresh Report, same as
+// Pipe. Its Iterable is a Rewinder only if this one is.
+//
+// Example:
+//   src, _ := fileio.Lines("users.csv")
+//   _, err := New(src).Trace("users.csv").TryMap(TraceConvert(parseUser))
+//   err => iter: map item 41 (bob;x at users.csv:42): invalid age "x"
+func (it *Iter) Trace(source string) *Iter {
+	t := &tracing{src: it.impl.item, source: source}
+	if _, ok := t.src.(Rewinder); ok {
+		return New(rewindableTracing{t})
+	}
+	return New(t)
+}
+
+// Untrace returns a new Iterator of the values of the *Traced items,
+// the other items are left as they are.
+func (it *Iter) Untrace() *Iter {
+	return newFromImpl(it.impl.apply(Untraced))
+}
+
+// Untraced returns the value of v if it is a *Traced, v otherwise.
+func Untraced(v interface{}) interface{} {
+	if t, ok := v.(*Traced); ok {
+		return t.Value
+	}
+	return v
+}
+
+// TraceMap wraps f so that it maps the value of a *Traced item, keeping
+// its Origin.
+func TraceMap(f MapFunc) MapFunc {
+	return func(v interface{}) interface{} {
+		if t, ok := v.(*Traced); ok {
+			return &Traced{f(t.Value), t.Origin}
+		}
+		return f(v)
+	}
+}
+
+// TraceFilter wraps f so that it filters the value of a *Traced item.
+func TraceFilter(f FilterFunc) FilterFunc {
+	return func(v interface{}) bool {
+		return f(Untraced(v))
+	}
+}
+
+// TraceConvert is like TraceMap for a ConvertFunc.
+func TraceConvert(f ConvertFunc) ConvertFunc {
+	return func(v interface{}) (interface{}, error) {
+		t, ok := v.(*Traced)
+		if !ok {
+			return f(v)
+		}
+		out, err := f(t.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &Traced{out, t.Origin}, nil
+	}
+}
+
+// tracing is the Iterable behind Trace.
+type tracing struct {
+	src    Iterable
+	source string
+	n      int
+}
+
+// Err returns the error of the source, if any.
+func (t *tracing) Err() error {
+	return errOf(t.src)
+}
+
+func (*tracing) New() (Iterable, error) {
+	return newElems()
+}
+
+func (*tracing) Add(interface{}) {
+	panic("iter: Add is not supported by a tracing Iterable")
+}
+
+func (t *tracing) Next() (interface{}, bool) {
+	v, more := t.src.Next()
+	if !more {
+		return nil, false
+	}
+	t.n++
+	return &Traced{v, Origin{Source: t.source, Record: t.n}}, true
+}
+
+// rewindableTracing is a tracing over a Rewinder.
+type rewindableTracing struct {
+	*tracing
+}
+
+func (t rewindableTracing) Rewind() {
+	t.src.(Rewinder).Rewind()
+	t.n = 0
+}
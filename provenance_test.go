@@ -0,0 +1,44 @@
+package iter
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestTrace(t *testing.T) {
+	it := New(FromStrings([]string{"1", "-2", "x", "4"})).Trace("nums.txt")
+	positive := it.Filter(TraceFilter(func(v interface{}) bool { return !strings.HasPrefix(v.(string), "-") }))
+
+	_, err := positive.TryMap(TraceConvert(func(v interface{}) (interface{}, error) {
+		return strconv.Atoi(v.(string))
+	}))
+	var merr *MapError
+	if !errors.As(err, &merr) || !strings.Contains(err.Error(), "(x at nums.txt:3)") {
+		t.Errorf("TryMap got error: %v, want one telling nums.txt:3", err)
+	}
+	if o := merr.Item.(*Traced).Origin; o != (Origin{Source: "nums.txt", Record: 3}) {
+		t.Errorf("Origin got: %+v, want: nums.txt:3", o)
+	}
+
+	it.Rewind()
+	got := it.Map(TraceMap(func(v interface{}) interface{} { return v.(string) + "!" })).Untrace().Collect()
+	if fmt.Sprint(got) != "[1! -2! x! 4!]" {
+		t.Errorf("TraceMap then Untrace got: %v, want: [1! -2! x! 4!]", got)
+	}
+
+	if s := (Origin{Source: "api", Page: 3, Record: 12}).String(); s != "api page 3:12" {
+		t.Errorf("Origin with a page got: %s, want: api page 3:12", s)
+	}
+
+	once := New(oneShot{FromInts([]int{0, 5, 10})}).Trace("nums.txt")
+	if once.Capabilities().Rewinder {
+		t.Error("Trace of a one-shot source is a Rewinder")
+	}
+	value := func(v interface{}) float64 { return float64(v.(*Traced).Value.(int)) }
+	if n := once.FilterOutliers(5, value).Count(); n != 3 {
+		t.Errorf("FilterOutliers of a traced one-shot source got %d items, want: 3", n)
+	}
+}
@@ -0,0 +1,140 @@
+package iter
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// seeded is the source of the randomized APIs called without a
+// *rand.Rand, see WithSeed. It's nil unless WithSeed is in effect.
+// rngMu guards it, as well as the draws from it.
+var (
+	rngMu  sync.Mutex
+	seeded *rand.Rand
+)
+
+// WithSeed makes the randomized APIs (Shuffle, Sample and SplitRandom)
+// called with a nil *rand.Rand draw from a source seeded with seed
+// instead of the global source of the math/rand package, so that a
+// pipeline can be reproduced, e.g. in CI or while debugging.
+//
+// Outside of a WithSeed scope, these APIs are not deterministic: the
+// global source of math/rand is seeded randomly at startup (Go 1.20 and
+// later), so every run draws differently. Pass a seeded *rand.Rand, or
+// call WithSeed, for reproducible outputs.
+//
+// WithSeed returns a function restoring the previous source, which
+// makes it handy as a test hook:
+//   defer iter.WithSeed(42)()
+func WithSeed(seed int64) (restore func()) {
+	rngMu.Lock()
+	defer rngMu.Unlock()
+
+	prev := seeded
+	seeded = rand.New(rand.NewSource(seed))
+	return func() {
+		rngMu.Lock()
+		defer rngMu.Unlock()
+		seeded = prev
+	}
+}
+
+// randFloat64 returns a float64 in [0.0, 1.0) from rng if not nil,
+// otherwise from the package source.
+func randFloat64(rng *rand.Rand) float64 {
+	if rng != nil {
+		return rng.Float64()
+	}
+	rngMu.Lock()
+	defer rngMu.Unlock()
+	if seeded != nil {
+		return seeded.Float64()
+	}
+	return rand.Float64()
+}
+
+// randIntn is like randFloat64 for an int in [0, n).
+func randIntn(rng *rand.Rand, n int) int {
+	if rng != nil {
+		return rng.Intn(n)
+	}
+	rngMu.Lock()
+	defer rngMu.Unlock()
+	if seeded != nil {
+		return seeded.Intn(n)
+	}
+	return rand.Intn(n)
+}
+
+// randInt63n is like randFloat64 for an int64 in [0, n).
+func randInt63n(rng *rand.Rand, n int64) int64 {
+	if rng != nil {
+		return rng.Int63n(n)
+	}
+	rngMu.Lock()
+	defer rngMu.Unlock()
+	if seeded != nil {
+		return seeded.Int63n(n)
+	}
+	return rand.Int63n(n)
+}
+
+// Shuffle returns a new Iterator contains the items of the Iterable in a
+// random order. The randomness comes from rng, see SplitRandom.
+//
+// Example:
+//   it := New(FromStrings([]string{"a", "b", "c"}))
+//   newit := it.Shuffle(rand.New(rand.NewSource(42)))
+//   produces a newit contains a permutation of []string{"a", "b", "c"}
+func (it *Iter) Shuffle(rng *rand.Rand) *Iter {
+	items := it.impl.slice()
+	for i := len(items) - 1; i > 0; i-- {
+		j := randIntn(rng, i+1)
+		items[i], items[j] = items[j], items[i]
+	}
+
+	newitem := it.impl.newItem()
+	for _, v := range items {
+		newitem.Add(v)
+	}
+	return newFromImpl(it.impl.derive("Shuffle", newitem, len(items), len(items)))
+}
+
+// Sample returns a new Iterator contains n items drawn uniformly at
+// random from the Iterable, in their original order. The Iterable is
+// read only once (reservoir sampling), so Sample suits streams of an
+// unknown size. If the Iterable has n items or less, all of them are
+// kept. The randomness comes from rng, see SplitRandom.
+//
+// Example:
+//   it := New(FromStrings(records))
+//   newit := it.Sample(100, nil)
+func (it *Iter) Sample(n int, rng *rand.Rand) *Iter {
+	type pick struct {
+		idx int
+		v   interface{}
+	}
+	var reservoir []pick
+
+	var read int
+	for {
+		elm, more := it.impl.next()
+		if !more {
+			break
+		}
+		if len(reservoir) < n {
+			reservoir = append(reservoir, pick{read, elm})
+		} else if j := randIntn(rng, read+1); j < n {
+			// Keep the reservoir sorted by the original position.
+			copy(reservoir[j:], reservoir[j+1:])
+			reservoir[n-1] = pick{read, elm}
+		}
+		read++
+	}
+
+	newitem := it.impl.newItem()
+	for _, p := range reservoir {
+		newitem.Add(p.v)
+	}
+	return newFromImpl(it.impl.derive("Sample", newitem, read, len(reservoir)))
+}
@@ -0,0 +1,40 @@
+package iter
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func TestWithSeed(t *testing.T) {
+	s := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	run := func() string {
+		defer WithSeed(7)()
+		it := New(FromStrings(s))
+		train, _ := it.SplitRandom(0.5, nil)
+		return fmt.Sprint(it.Shuffle(nil).Collect(), it.Sample(3, nil).Collect(), train.Collect())
+	}
+	if a, b := run(), run(); a != b {
+		t.Errorf("seeded runs differ: %s and %s", a, b)
+	}
+}
+
+func TestShuffleSample(t *testing.T) {
+	defer WithSeed(1)()
+	s := []string{"a", "b", "c", "d", "e"}
+
+	shuffled := New(FromStrings(s)).Shuffle(nil).Collect().([]string)
+	sorted := append([]string(nil), shuffled...)
+	sort.Strings(sorted)
+	if fmt.Sprint(sorted) != fmt.Sprint(s) {
+		t.Errorf("Shuffle got: %v, not a permutation of %v", shuffled, s)
+	}
+
+	sample := New(FromStrings(s)).Sample(3, nil).Collect().([]string)
+	if len(sample) != 3 || !sort.StringsAreSorted(sample) {
+		t.Errorf("Sample(3) got: %v, want 3 items in their original order", sample)
+	}
+	if all := New(FromStrings(s)).Sample(10, nil).Collect(); fmt.Sprint(all) != fmt.Sprint(s) {
+		t.Errorf("Sample(10) of 5 items got: %v, want: %v", all, s)
+	}
+}
@@ -0,0 +1,263 @@
+package iter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Field returns a MapFunc which projects a struct item (or a pointer to
+// a struct) to the value of its field called name, so a pipeline over
+// []MyStruct doesn't need a custom closure for every projection.
+//
+// The MapFunc panics if an item isn't a struct or doesn't have such an
+// exported field.
+//
+// Example:
+//   type Person struct{ Name string; Age int }
+//   it.Map(Field("Name")) turns Person{"Ann", 30} into "Ann"
+func Field(name string) MapFunc {
+	return func(v interface{}) interface{} {
+		return fieldOf(v, name).Interface()
+	}
+}
+
+// Fields returns a MapFunc which projects a struct item (or a pointer to
+// a struct) to a map[string]interface{} of the given fields.
+//
+// Same as Field, the MapFunc panics if an item isn't a struct or doesn't
+// have one of the exported fields.
+//
+// Example:
+//   type Person struct{ Name string; Age int; City string }
+//   it.Map(Fields("Name", "Age")) turns Person{"Ann", 30, "Oslo"} into
+//   map[string]interface{}{"Name": "Ann", "Age": 30}
+func Fields(names ...string) MapFunc {
+	return func(v interface{}) interface{} {
+		m := make(map[string]interface{}, len(names))
+		for _, name := range names {
+			m[name] = fieldOf(v, name).Interface()
+		}
+		return m
+	}
+}
+
+// TagKey returns a key extractor for struct items (or pointers to
+// structs) which yields the value of the field tagged `iter:"name"`, so
+// the key used by the grouping, sorting or de-duplicating APIs can be
+// declared on the domain struct instead of in a closure.
+//
+// The tag may carry options after a comma, e.g. `iter:"status,desc"`,
+// which are ignored by TagKey, see TagSortKeys.
+// The extractor panics if an item isn't a struct or has no such field.
+//
+// Example:
+//   type Event struct {
+//      Status string    `iter:"status,desc"`
+//      At     time.Time `iter:"at"`
+//   }
+//   key := TagKey("status")
+//   key(Event{Status: "ok"}) => "ok"
+func TagKey(name string) func(interface{}) interface{} {
+	return func(v interface{}) interface{} {
+		rv := structOf(v)
+		f := taggedField(rv.Type(), name)
+		return rv.FieldByIndex(f.index).Interface()
+	}
+}
+
+// TagSortKeys returns a SortKey for every given tag name, in order, to
+// be used by SortByKeys. A field tagged with the "desc" option, e.g.
+// `iter:"status,desc"`, is sorted in descending order.
+//
+// Example:
+//   it.SortByKeys(TagSortKeys("status", "at")...)
+//   sorts the Events of the TagKey example by Status desc then At asc.
+func TagSortKeys(names ...string) []SortKey {
+	keys := make([]SortKey, len(names))
+	for i, name := range names {
+		name := name
+		// Whether the key is desc is only known once the struct type is
+		// seen, so the whole items are compared.
+		keys[i].Compare = func(a, b interface{}) int {
+			ra, rb := structOf(a), structOf(b)
+			f := taggedField(ra.Type(), name)
+			c := compare(ra.FieldByIndex(f.index).Interface(), rb.FieldByIndex(f.index).Interface())
+			if f.desc {
+				return -c
+			}
+			return c
+		}
+	}
+	return keys
+}
+
+type tagged struct {
+	index []int
+	desc  bool
+}
+
+// tagCache maps a struct type to its tagged fields by tag name.
+var tagCache sync.Map // map[reflect.Type]map[string]tagged
+
+func taggedField(t reflect.Type, name string) tagged {
+	fields, ok := tagCache.Load(t)
+	if !ok {
+		m := map[string]tagged{}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag, ok := f.Tag.Lookup("iter")
+			if !ok || f.PkgPath != "" {
+				continue
+			}
+			opts := strings.Split(tag, ",")
+			tf := tagged{index: f.Index}
+			for _, opt := range opts[1:] {
+				if opt == "desc" {
+					tf.desc = true
+				}
+			}
+			m[opts[0]] = tf
+		}
+		fields, _ = tagCache.LoadOrStore(t, m)
+	}
+
+	f, ok := fields.(map[string]tagged)[name]
+	if !ok {
+		panic(fmt.Sprintf("iter: %s has no exported field tagged `iter:%q`", t, name))
+	}
+	return f
+}
+
+// structOf dereferences v down to a struct value.
+func structOf(v interface{}) reflect.Value {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("iter: %#v (%T) is not a struct", v, v))
+	}
+	return rv
+}
+
+func fieldOf(v interface{}, name string) reflect.Value {
+	rv := structOf(v)
+	f, ok := rv.Type().FieldByName(name)
+	if !ok || f.PkgPath != "" {
+		panic(fmt.Sprintf("iter: %T has no exported field %q", v, name))
+	}
+	return rv.FieldByIndex(f.Index)
+}
+
+// FromSlice creates an Iterable of the items of s, a slice of any type,
+// by reflection, so that a []T doesn't need a hand-written Iterable. The
+// Iterable is an Enumerator, a Rewinder, a Resetter, a Lener, a Seeker
+// and a FromIter whose To returns a []T, e.g. for Collect.
+//
+// The Iterables created by its New API hold a []T as well, unless an item
+// isn't assignable to T, e.g. after a Map to another type, in which case
+// they fall back to a []interface{}. An error is returned if s isn't a
+// slice.
+//
+// Example:
+//   type Person struct{ Name string; Age int }
+//   src, err := FromSlice([]Person{{"Ann", 30}, {"Bob", 17}})
+//   adults := New(src).Filter(isAdult).Collect().([]Person)
+func FromSlice(s interface{}) (Iterable, error) {
+	rv := reflect.ValueOf(s)
+	if rv.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("iter: FromSlice of %T, want a slice", s)
+	}
+	return &reflSlice{idx: -1, data: rv}, nil
+}
+
+// ToSlice appends every item of the Iterable to the slice pointed to by
+// target, e.g. a *[]T. It is the same as CollectTo: an error is returned
+// if target isn't a pointer to a slice, an item isn't assignable to T or
+// the Iterable is consumed, target is left untouched then, and a nil
+// item appends a zero value.
+//
+// Same as Each, the Iterable is rewinded afterwards if it is a Rewinder.
+//
+// Example:
+//   var names []string
+//   err := it.Map(Field("Name")).ToSlice(&names)
+func (it *Iter) ToSlice(target interface{}) error {
+	return it.CollectTo(target)
+}
+
+// assignable returns the value of v to be stored as a t.
+func assignable(v interface{}, t reflect.Type) (reflect.Value, error) {
+	if v == nil {
+		switch t.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+			return reflect.Zero(t), nil
+		}
+	} else if rv := reflect.ValueOf(v); rv.Type().AssignableTo(t) {
+		return rv, nil
+	}
+	return reflect.Value{}, fmt.Errorf("iter: %#v (%T) is not assignable to %s", v, v, t)
+}
+
+// reflSlice is the Iterable behind FromSlice.
+type reflSlice struct {
+	idx  int
+	data reflect.Value
+}
+
+func (rs *reflSlice) New() (Iterable, error) {
+	return &reflSlice{idx: -1, data: reflect.MakeSlice(rs.data.Type(), 0, 0)}, nil
+}
+
+func (rs *reflSlice) Next() (interface{}, bool) {
+	_, v, more := rs.Enumerate()
+	return v, more
+}
+
+func (rs *reflSlice) Enumerate() (int, interface{}, bool) {
+	rs.idx++
+	if rs.idx < rs.data.Len() {
+		return rs.idx, rs.data.Index(rs.idx).Interface(), true
+	}
+	return -1, nil, false
+}
+
+func (rs *reflSlice) Rewind() {
+	rs.idx = -1
+}
+
+func (rs *reflSlice) Reset() {
+	rs.Rewind()
+	rs.data = reflect.MakeSlice(rs.data.Type(), 0, 0)
+}
+
+func (rs *reflSlice) Add(obj interface{}) {
+	rv, err := assignable(obj, rs.data.Type().Elem())
+	if err != nil {
+		// Fall back to []interface{}, e.g. for the outcome of a Map.
+		items := make([]interface{}, rs.data.Len(), rs.data.Len()+1)
+		for i := range items {
+			items[i] = rs.data.Index(i).Interface()
+		}
+		rs.data, rv = reflect.ValueOf(items), reflect.ValueOf(&obj).Elem()
+	}
+	rs.data = reflect.Append(rs.data, rv)
+}
+
+func (rs *reflSlice) Len() int {
+	return rs.data.Len()
+}
+
+func (rs *reflSlice) Seek(i int) {
+	rs.idx = i - 1
+}
+
+func (rs *reflSlice) To() interface{} {
+	return rs.data.Interface()
+}
+
+func (rs *reflSlice) String() string {
+	return fmt.Sprintf("%+v", rs.data.Interface())
+}
@@ -0,0 +1,128 @@
+package iter
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+type person struct {
+	Name string
+	Age  int
+	City string
+	id   int
+}
+
+func TestField(t *testing.T) {
+	people := []interface{}{person{"Ann", 30, "Oslo", 1}, &person{"Bob", 25, "Rome", 2}}
+
+	var names []string
+	for _, p := range people {
+		names = append(names, Field("Name")(p).(string))
+	}
+	if fmt.Sprint(names) != "[Ann Bob]" {
+		t.Errorf("Field(\"Name\") got: %v, want: [Ann Bob]", names)
+	}
+
+	m := Fields("Name", "Age")(people[1]).(map[string]interface{})
+	if len(m) != 2 || m["Name"] != "Bob" || m["Age"] != 25 {
+		t.Errorf("Fields(\"Name\", \"Age\") got: %v", m)
+	}
+
+	for _, tc := range []struct {
+		v    interface{}
+		name string
+	}{
+		{"not a struct", "Name"},
+		{people[0], "Missing"},
+		{people[0], "id"},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Field(%q) of %#v doesn't panic", tc.name, tc.v)
+				}
+			}()
+			Field(tc.name)(tc.v)
+		}()
+	}
+}
+
+type event struct {
+	Status string    `iter:"status,desc"`
+	At     time.Time `iter:"at"`
+	Note   string
+}
+
+func TestTagKeys(t *testing.T) {
+	t0 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []event{
+		{"fail", t0.Add(2 * time.Hour), "a"},
+		{"ok", t0.Add(time.Hour), "b"},
+		{"fail", t0, "c"},
+		{"ok", t0, "d"},
+	}
+
+	if got := TagKey("status")(&events[1]); got != "ok" {
+		t.Errorf("TagKey(\"status\") got: %v, want: ok", got)
+	}
+
+	it := New(&elems{idx: -1})
+	for _, e := range events {
+		it.impl.item.Add(e)
+	}
+	var notes []string
+	it.SortByKeys(TagSortKeys("status", "at")...).Each(func(v interface{}) {
+		notes = append(notes, v.(event).Note)
+	})
+	if fmt.Sprint(notes) != "[d b c a]" {
+		t.Errorf("SortByKeys(TagSortKeys(\"status\", \"at\")) got: %v, want: [d b c a]", notes)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("TagKey of an untagged field doesn't panic")
+		}
+	}()
+	TagKey("note")(events[0])
+}
+
+func TestFromSlice(t *testing.T) {
+	if _, err := FromSlice("Ann"); err == nil {
+		t.Error("FromSlice of a string got no error")
+	}
+
+	src, err := FromSlice([]person{{Name: "Ann", Age: 30}, {Name: "Bob", Age: 17}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	it := New(src)
+	adults := it.Filter(func(v interface{}) bool { return v.(person).Age >= 18 }).Collect().([]person)
+	if len(adults) != 1 || adults[0].Name != "Ann" {
+		t.Errorf("FromSlice then Filter got: %v, want: [Ann]", adults)
+	}
+
+	it.Rewind()
+	names := []string{"Zoe"}
+	if err := it.Map(Field("Name")).ToSlice(&names); err != nil || fmt.Sprint(names) != "[Zoe Ann Bob]" {
+		t.Errorf("ToSlice got: %v, %v, want: [Zoe Ann Bob]", names, err)
+	}
+
+	var ages []string
+	if err := New(FromInts([]int{1})).ToSlice(&ages); err == nil {
+		t.Error("ToSlice of ints into a []string got no error")
+	}
+	if err := New(FromInts([]int{1})).ToSlice(ages); err == nil {
+		t.Error("ToSlice into a slice instead of a pointer got no error")
+	}
+
+	// Same as CollectTo, target is untouched on errors and a nil item
+	// appends a zero value.
+	mixed := New(&elems{idx: -1, data: []interface{}{"a", nil, 1}, size: 3})
+	if err := mixed.ToSlice(&ages); err == nil || len(ages) != 0 {
+		t.Errorf("ToSlice of a string then an int got: %q, %v, want an untouched slice and an error", ages, err)
+	}
+	if err := New(&elems{idx: -1, data: []interface{}{"a", nil}, size: 2}).ToSlice(&ages); err != nil || fmt.Sprintf("%q", ages) != `["a" ""]` {
+		t.Errorf("ToSlice with a nil item got: %q, %v, want: [\"a\" \"\"]", ages, err)
+	}
+}
@@ -0,0 +1,92 @@
+package iter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StageReport tells how many items a single stage has consumed.
+type StageReport struct {
+	// Stage is the name of the API producing the stage, e.g. "Filter".
+	Stage string
+	// Read is the number of items the stage has read from its input.
+	Read int
+	// Emitted is the number of items the stage has produced.
+	Emitted int
+	// Dropped is the number of items the stage has filtered out,
+	// a negative number means the stage has produced extra items,
+	// e.g. Chain.
+	Dropped int
+}
+
+// ConsumptionReport tells how the items have flowed through the stages
+// which produce an Iterator, e.g. for data-quality dashboards built on top
+// of pipeline runs.
+type ConsumptionReport struct {
+	// Read is the number of items read from the source Iterable.
+	Read int
+	// Emitted is the number of items produced by the last stage.
+	Emitted int
+	// Stages are ordered from the source to the last stage.
+	Stages []StageReport
+}
+
+// String implements the Stringer interface for ConsumptionReport.
+func (r *ConsumptionReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "read %d, emitted %d", r.Read, r.Emitted)
+	for _, st := range r.Stages {
+		fmt.Fprintf(&b, "; %s: %d -> %d", st.Stage, st.Read, st.Emitted)
+	}
+	return b.String()
+}
+
+// Report returns how the items have flowed through every stage producing
+// this Iterator, e.g. how many items a Filter has dropped.
+//
+// Only the stages which materialize a new Iterable are recorded, lazy
+// ones such as Patch, as well as the Iterators created by New, start a
+// fresh report.
+//
+// Example:
+//   it := New(FromStrings([]string{"a", "b", "c"})).
+//      Filter(func(v interface{}) bool { return v.(string) != "b" }).
+//      Map(func(v interface{}) interface{} { return strings.ToUpper(v.(string)) })
+//   it.Report() => read 3, emitted 2; Filter: 3 -> 2; Map: 2 -> 2
+func (it *Iter) Report() *ConsumptionReport {
+	return it.impl.report()
+}
+
+// Drain consumes whatever left in the Iterable and returns the report of
+// all the stages including the drain itself.
+//
+// Drain does not Rewind the Iterable.
+func (it *Iter) Drain() *ConsumptionReport {
+	var n int
+	for {
+		if _, more := it.impl.next(); !more {
+			break
+		}
+		n++
+	}
+	return it.impl.derive("Drain", it.impl.item, n, n).report()
+}
+
+func (it *iter) report() *ConsumptionReport {
+	var stages []StageReport
+	for _, st := range it.stats {
+		stages = append(stages, StageReport{
+			Stage:   st.stage,
+			Read:    st.read,
+			Emitted: st.emitted,
+			Dropped: st.read - st.emitted,
+		})
+	}
+
+	r := &ConsumptionReport{Stages: stages}
+	if len(stages) > 0 {
+		r.Read = stages[0].Read
+		r.Emitted = stages[len(stages)-1].Emitted
+	}
+	return r
+}
@@ -0,0 +1,40 @@
+package iter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	it := New(FromStrings([]string{"a", "b", "c"})).
+		Filter(func(v interface{}) bool { return v.(string) != "b" }).
+		Map(func(v interface{}) interface{} { return strings.ToUpper(v.(string)) })
+
+	r := it.Report()
+	if got, want := r.String(), "read 3, emitted 2; Filter: 3 -> 2; Map: 2 -> 2"; got != want {
+		t.Errorf("Report got: %q, want: %q", got, want)
+	}
+	if r.Stages[0].Dropped != 1 || r.Stages[1].Dropped != 0 {
+		t.Errorf("Report got dropped items: %d and %d, want: 1 and 0", r.Stages[0].Dropped, r.Stages[1].Dropped)
+	}
+
+	r = it.Drain()
+	if got, want := r.String(), "read 3, emitted 2; Filter: 3 -> 2; Map: 2 -> 2; Drain: 2 -> 2"; got != want {
+		t.Errorf("Drain got: %q, want: %q", got, want)
+	}
+
+	r = New(FromStrings([]string{"a"})).Drain()
+	if r.Read != 1 || r.Emitted != 1 {
+		t.Errorf("Drain on a source got read: %d emitted: %d, want 1 and 1", r.Read, r.Emitted)
+	}
+	if r = New(FromStrings([]string{"a"})).Report(); r.Read != 0 || len(r.Stages) != 0 {
+		t.Errorf("Report on a source got: %v, want an empty report", r)
+	}
+
+	// The intermediate copies are not kept by the last Iterator.
+	src := FromStrings([]string{"a", "b"})
+	last := New(src).Map(func(v interface{}) interface{} { return v }).Filter(func(interface{}) bool { return true })
+	if got := last.impl.sources; len(got) != 1 || got[0] != src {
+		t.Errorf("Filter after Map kept the Iterables: %v, want only the source", got)
+	}
+}
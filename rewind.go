@@ -0,0 +1,73 @@
+package iter
+
+// RewindPolicy tells what the read-only terminal APIs (Each, ParEach,
+// Count, Nth, the Collect ones but Collect itself, ToSlice, ToChan, Pull,
+// BinarySearch, InferSchema, ChecksumAll, ServeJSON, ServeNDJSON,
+// PostJSON and the searches: First, Last, Find, PositionOf and Contains)
+// do with the Iterable once they are done, see WithRewind.
+type RewindPolicy int
+
+const (
+	// RewindIfPossible rewinds the Iterable after the terminals but
+	// the searches if it is a Rewinder, the searches leave it where
+	// they stopped.
+	// This is the default.
+	RewindIfPossible RewindPolicy = iota
+	// AlwaysRewind is like RewindIfPossible but the searches rewind the
+	// Iterable as well.
+	AlwaysRewind
+	// NeverRewind leaves the Iterable where every terminal stopped, the
+	// caller has to call Rewind explicitly.
+	NeverRewind
+	// ErrorIfConsuming is like RewindIfPossible but a terminal refuses
+	// to read an Iterable which is not a Rewinder, as it would be
+	// consumed. The terminal returns zero values, the violation is
+	// recorded as a *MisuseError with ErrNotRewinder returned by Err.
+	ErrorIfConsuming
+)
+
+// WithRewind sets the RewindPolicy of the Iterator and returns it.
+// The Iterators derived from it have the same RewindPolicy.
+//
+// Example:
+//   it := New(oneShotSource).WithRewind(ErrorIfConsuming)
+//   it.Count() => 0
+//   errors.Is(it.Err(), ErrNotRewinder) => true
+func (it *Iter) WithRewind(p RewindPolicy) *Iter {
+	it.impl.policy = p
+	return it
+}
+
+// Rewind rewinds the Iterable along with every Iterable this Iterator is
+// built from: the one given to New and the other Iterables combined by
+// Zip and Chain, so that the parts of a composite Iterator are reusable
+// as well, e.g. to Zip them again. The copies made by the stages in
+// between are not kept, hence not rewound. The Iterables which are not
+// Rewinders are left as they are. Rewind ignores the RewindPolicy.
+//
+// Example:
+//   a, b := New(FromStrings(xs)), FromStrings(ys)
+//   zipped := a.Zip(b)
+//   zipped.Rewind()
+//   a.Zip(b) zips xs and ys again
+func (it *Iter) Rewind() {
+	it.impl.rewindItem()
+	for _, src := range it.impl.sources {
+		if r, ok := src.(Rewinder); ok {
+			r.Rewind()
+		}
+	}
+}
+
+// guard tells whether a terminal may read the Iterable under the
+// RewindPolicy, recording the violation otherwise.
+func (it *iter) guard() bool {
+	if it.policy != ErrorIfConsuming {
+		return true
+	}
+	if _, ok := it.item.(Rewinder); ok {
+		return true
+	}
+	it.misuse(ErrNotRewinder)
+	return false
+}
@@ -0,0 +1,78 @@
+package iter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRewindPolicy(t *testing.T) {
+	isB := func(v interface{}) bool { return v.(string) == "b" }
+	in := []string{"a", "b", "c"}
+
+	it := New(FromStrings(in)).WithRewind(NeverRewind)
+	if n := it.Count(); n != 3 {
+		t.Errorf("NeverRewind first Count got: %d, want: 3", n)
+	}
+	var seen int
+	it.Each(func(interface{}) { seen++ })
+	if seen != 0 {
+		t.Errorf("NeverRewind Each after Count saw %d items, want: 0", seen)
+	}
+
+	it = New(FromStrings(in)).WithRewind(AlwaysRewind)
+	if i, _, _ := it.First(isB); i != 1 {
+		t.Errorf("AlwaysRewind First got index %d, want: 1", i)
+	}
+	if it.Position() != 0 || it.Nth(0) != "a" {
+		t.Errorf("AlwaysRewind First didn't rewind, position: %d", it.Position())
+	}
+
+	it = New(FromStrings(in))
+	it.First(isB)
+	if it.Position() != 2 {
+		t.Errorf("RewindIfPossible First got position %d, want: 2", it.Position())
+	}
+
+	once := New(oneShot{FromStrings(in)}).WithRewind(ErrorIfConsuming)
+	if n := once.Count(); n != 0 || !errors.Is(once.Err(), ErrNotRewinder) {
+		t.Errorf("ErrorIfConsuming Count got: %d with error: %v", n, once.Err())
+	}
+	var out []string
+	if err := once.CollectTo(&out); !errors.Is(err, ErrNotRewinder) || out != nil {
+		t.Errorf("ErrorIfConsuming CollectTo got: %v with error: %v", out, err)
+	}
+	if got := once.Filter(isB).Err(); !errors.Is(got, ErrNotRewinder) {
+		t.Errorf("ErrorIfConsuming derived Iterator got error: %v", got)
+	}
+
+	rw := New(FromStrings(in)).WithRewind(ErrorIfConsuming)
+	if n := rw.Count(); n != 3 || rw.Err() != nil {
+		t.Errorf("ErrorIfConsuming on a Rewinder Count got: %d with error: %v", n, rw.Err())
+	}
+}
+
+func TestRewind(t *testing.T) {
+	xs, ys, zs := FromStrings([]string{"a", "b"}), FromStrings([]string{"1", "2"}), FromStrings([]string{"c"})
+	src := New(xs)
+	chained := src.Map(func(v interface{}) interface{} { return v }).Chain(zs).Zip(ys)
+	if n := chained.Count(); n != 2 {
+		t.Fatalf("Chain then Zip got %d items, want: 2", n)
+	}
+
+	chained.Rewind()
+	if got := fmt.Sprint(src.Zip(ys).Collect()); got != "[{a, 1} {b, 2}]" {
+		t.Errorf("Zip after Rewind got: %s, want: [{a, 1} {b, 2}]", got)
+	}
+	if v, more := zs.Next(); !more || v != "c" {
+		t.Errorf("Chain source after Rewind got: %v, %v, want: c, true", v, more)
+	}
+
+	merged := MergeBy(func(a, b interface{}) int { return strings.Compare(a.(string), b.(string)) },
+		FromStrings([]string{"a", "c"}), FromStrings([]string{"b"}))
+	merged.Each(func(interface{}) {})
+	if got := fmt.Sprint(merged.impl.slice()); got != "[a b c]" {
+		t.Errorf("MergeBy traversed again got: %s, want: [a b c]", got)
+	}
+}
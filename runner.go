@@ -0,0 +1,163 @@
+package iter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// ErrOverlap is returned by Runner.RunOnce when a run of the same Runner
+// is still in progress.
+var ErrOverlap = errors.New("iter: a run is already in progress")
+
+// Schedule tells when a Runner runs next, given the end of the last run
+// (or the start of the Runner). A cron library can be plugged in through
+// this interface.
+type Schedule interface {
+	Next(time.Time) time.Time
+}
+
+// Every is a Schedule running at a fixed interval. The interval counts
+// from the end of the last run, so that a run longer than the interval
+// is never followed by a burst of catch-up runs.
+type Every time.Duration
+
+// Next implements the Schedule interface for Every.
+func (d Every) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(d))
+}
+
+// RunStats tells how a single run of a Runner went.
+type RunStats struct {
+	// Start is when the run has started.
+	Start time.Time
+	// Duration is how long the run has taken.
+	Duration time.Duration
+	// Read is the number of items read from the Source.
+	Read int
+	// Emitted is the number of items passed to the Sink.
+	Emitted int
+	// Err is the error the run has stopped at, if any.
+	Err error
+}
+
+// Runner runs a Pipeline against a freshly opened Source on a Schedule,
+// e.g. a recurring data pull every few minutes.
+//
+// Runs never overlap: the next run is scheduled once the last one has
+// ended, and RunOnce refuses to start while a run is in progress.
+// A failed run doesn't stop the Runner, it is reported to OnRun.
+//
+// Example:
+//   r := &Runner{
+//      Pipeline: p,
+//      Source:   func(ctx context.Context) (Iterable, error) { return fetch(ctx) },
+//      Sink:     store,
+//      Schedule: Every(5 * time.Minute),
+//      Jitter:   10 * time.Second,
+//      OnRun:    func(s RunStats) { log.Printf("%+v", s) },
+//   }
+//   err := r.Run(ctx)
+type Runner struct {
+	// Pipeline is the Pipeline to run, nil passes the items through.
+	Pipeline *Pipeline
+	// Source opens the Iterable of a run. If the Iterable is an
+	// io.Closer, it's closed at the end of the run.
+	Source func(context.Context) (Iterable, error)
+	// Sink receives the items coming out of the Pipeline, see
+	// RunConcurrent.
+	Sink func(interface{}) error
+	// Schedule tells when to run next.
+	Schedule Schedule
+	// Jitter delays every run by a random duration in [0, Jitter), so
+	// that many Runners don't hit a source at the same time.
+	Jitter time.Duration
+	// Options configures every run, its Context is overridden by the
+	// one given to Run or RunOnce.
+	Options ConcurrentOptions
+	// OnRun, if not nil, receives the stats of every run.
+	OnRun func(RunStats)
+
+	running int32
+}
+
+// Run runs the Pipeline on the Schedule until ctx is done, which is
+// returned. The first run happens at the first time of the Schedule.
+func (r *Runner) Run(ctx context.Context) error {
+	next := r.Schedule.Next(time.Now())
+	for {
+		wait := time.Until(next)
+		if r.Jitter > 0 {
+			wait += time.Duration(randInt63n(nil, int64(r.Jitter)))
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		r.RunOnce(ctx)
+		next = r.Schedule.Next(time.Now())
+	}
+}
+
+// RunOnce runs the Pipeline once, right away, and returns the stats of
+// the run, which are passed to OnRun as well. RunOnce returns stats with
+// ErrOverlap when a run is still in progress, which are not passed to
+// OnRun.
+func (r *Runner) RunOnce(ctx context.Context) RunStats {
+	stats := RunStats{Start: time.Now()}
+	if !atomic.CompareAndSwapInt32(&r.running, 0, 1) {
+		stats.Err = ErrOverlap
+		return stats
+	}
+	defer atomic.StoreInt32(&r.running, 0)
+
+	stats.Err = r.run(ctx, &stats)
+	stats.Duration = time.Since(stats.Start)
+	if r.OnRun != nil {
+		r.OnRun(stats)
+	}
+	return stats
+}
+
+func (r *Runner) run(ctx context.Context, stats *RunStats) error {
+	src, err := r.Source(ctx)
+	if err != nil {
+		return err
+	}
+	if c, ok := src.(io.Closer); ok {
+		defer c.Close()
+	}
+
+	p := r.Pipeline
+	if p == nil {
+		p = NewPipeline()
+	}
+	opts := r.Options
+	opts.Context = ctx
+	// RunConcurrent has waited for the source goroutine when it returns,
+	// so Read is safe to use afterwards.
+	return p.RunConcurrent(&counted{Iterable: src, n: &stats.Read}, func(v interface{}) error {
+		stats.Emitted++
+		return r.Sink(v)
+	}, opts)
+}
+
+// counted is an Iterable counting the items read through Next.
+type counted struct {
+	Iterable
+	n *int
+}
+
+func (c *counted) Next() (interface{}, bool) {
+	v, more := c.Iterable.Next()
+	if more {
+		*c.n++
+	}
+	return v, more
+}
@@ -0,0 +1,95 @@
+package iter
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type closingStrings struct {
+	*IterStrings
+	closed *int
+}
+
+func (c closingStrings) Close() error {
+	*c.closed++
+	return nil
+}
+
+func TestRunner(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var closed int
+	var got []interface{}
+	var stats []RunStats
+	r := &Runner{
+		Pipeline: NewPipeline().Filter(func(v interface{}) bool { return v.(string) != "b" }),
+		Source: func(context.Context) (Iterable, error) {
+			return closingStrings{FromStrings([]string{"a", "b", "c"}), &closed}, nil
+		},
+		Sink: func(v interface{}) error {
+			got = append(got, v)
+			return nil
+		},
+		Schedule: Every(time.Millisecond),
+		Jitter:   time.Millisecond,
+		OnRun: func(s RunStats) {
+			stats = append(stats, s)
+			if len(stats) == 2 {
+				cancel()
+			}
+		},
+	}
+
+	if err := r.Run(ctx); err != context.Canceled {
+		t.Errorf("Run got error: %v, want: %v", err, context.Canceled)
+	}
+	if len(stats) != 2 || closed != 2 {
+		t.Fatalf("Run got %d runs closing the source %d times, want: 2", len(stats), closed)
+	}
+	for _, s := range stats {
+		if s.Read != 3 || s.Emitted != 2 || s.Err != nil {
+			t.Errorf("Run got stats: %+v, want: read 3, emitted 2", s)
+		}
+	}
+	if len(got) != 4 {
+		t.Errorf("Run got items: %v, want: [a c a c]", got)
+	}
+}
+
+func TestRunnerOnce(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	r := &Runner{
+		Source: func(context.Context) (Iterable, error) {
+			close(started)
+			return FromStrings([]string{"a"}), nil
+		},
+		Sink: func(interface{}) error {
+			<-release
+			return errors.New("sink failed")
+		},
+	}
+
+	done := make(chan RunStats)
+	go func() {
+		done <- r.RunOnce(context.Background())
+	}()
+	<-started
+	if s := r.RunOnce(context.Background()); s.Err != ErrOverlap {
+		t.Errorf("RunOnce during a run got error: %v, want: %v", s.Err, ErrOverlap)
+	}
+	close(release)
+	if s := <-done; s.Err == nil || !strings.Contains(s.Err.Error(), "sink failed") {
+		t.Errorf("RunOnce got error: %v, want: sink failed", s.Err)
+	}
+
+	r.Source = func(context.Context) (Iterable, error) {
+		return nil, errors.New("unavailable")
+	}
+	if s := r.RunOnce(context.Background()); s.Err == nil || s.Read != 0 {
+		t.Errorf("RunOnce with a failing Source got: %+v", s)
+	}
+}
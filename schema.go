@@ -0,0 +1,120 @@
+package iter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrSchemaMismatch is the error of a MatchSchema rule rejecting an item.
+var ErrSchemaMismatch = errors.New("iter: schema mismatch")
+
+// Schema is the shape of an item: its dynamic type and, for a map, a
+// struct or a pointer to a struct, its set of fields.
+type Schema struct {
+	// Type is the dynamic type of the item, nil for a nil item.
+	Type reflect.Type
+	// Fields are the sorted keys of a map, as formatted by %v, or the
+	// exported fields of a struct in their declaration order.
+	Fields []string
+}
+
+// SchemaOf returns the Schema of v.
+func SchemaOf(v interface{}) Schema {
+	s := Schema{Type: reflect.TypeOf(v)}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && rv.Type().Elem().Kind() == reflect.Struct {
+		if rv.IsNil() {
+			return s
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Map:
+		for _, k := range rv.MapKeys() {
+			s.Fields = append(s.Fields, fmt.Sprint(k.Interface()))
+		}
+		sort.Strings(s.Fields)
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			if f := rv.Type().Field(i); f.PkgPath == "" {
+				s.Fields = append(s.Fields, f.Name)
+			}
+		}
+	}
+	return s
+}
+
+// Equal tells whether two Schemas are the same.
+func (s Schema) Equal(other Schema) bool {
+	if s.Type != other.Type || len(s.Fields) != len(other.Fields) {
+		return false
+	}
+	for i := range s.Fields {
+		if s.Fields[i] != other.Fields[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements the Stringer interface for Schema, e.g.
+// "map[string]interface {}{age,name}".
+func (s Schema) String() string {
+	if s.Type == nil {
+		return "nil"
+	}
+	if s.Fields == nil {
+		return s.Type.String()
+	}
+	return fmt.Sprintf("%s{%s}", s.Type, strings.Join(s.Fields, ","))
+}
+
+// InferSchema returns the distinct Schemas of the items of the Iterable,
+// in the order they are first seen, so that a heterogeneous Iterable is
+// spotted before a type assertion panics downstream: a homogeneous one
+// has a single Schema.
+//
+// Same as Each, the Iterable is rewinded afterwards if it is a Rewinder.
+//
+// Example:
+//   src, _ := FromSlice([]interface{}{
+//      map[string]interface{}{"name": "Ann", "age": 30},
+//      map[string]interface{}{"name": "Bob"},
+//   })
+//   New(src).InferSchema() => [map[string]interface {}{age,name} map[string]interface {}{name}]
+func (it *Iter) InferSchema() []Schema {
+	if !it.impl.guard() {
+		return nil
+	}
+	var schemas []Schema
+	it.impl.each(func(v interface{}) {
+		s := SchemaOf(v)
+		for _, seen := range schemas {
+			if seen.Equal(s) {
+				return
+			}
+		}
+		schemas = append(schemas, s)
+	})
+	return schemas
+}
+
+// MatchSchema returns a rule for Validate rejecting the items whose Schema
+// isn't want, with an error wrapping ErrSchemaMismatch, so that the
+// Schema inferred from a sample can be enforced on the whole stream.
+//
+// Example:
+//   want := SchemaOf(Event{})
+//   valid, invalid := it.Validate(MatchSchema(want))
+func MatchSchema(want Schema) func(interface{}) error {
+	return func(v interface{}) error {
+		if got := SchemaOf(v); !got.Equal(want) {
+			return fmt.Errorf("%w: %s, want %s", ErrSchemaMismatch, got, want)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,33 @@
+package iter
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestInferSchema(t *testing.T) {
+	src, _ := FromSlice([]interface{}{
+		map[string]interface{}{"name": "Ann", "age": 30},
+		&person{Name: "Bob"},
+		map[string]interface{}{"age": 17, "name": "Cid"},
+		nil,
+	})
+	it := New(src)
+
+	got := fmt.Sprint(it.InferSchema())
+	want := "[map[string]interface {}{age,name} *iter.person{Name,Age,City} nil]"
+	if got != want {
+		t.Errorf("InferSchema got: %s, want: %s", got, want)
+	}
+
+	want0 := SchemaOf(map[string]interface{}{"name": "", "age": 0})
+	valid, invalid := it.Validate(MatchSchema(want0))
+	if n := valid.Count(); n != 2 {
+		t.Errorf("Validate with MatchSchema got %d valid items, want: 2", n)
+	}
+	errs := invalid.Collect().([]interface{})
+	if len(errs) != 2 || !errors.Is(errs[0].(error), ErrSchemaMismatch) {
+		t.Errorf("Validate with MatchSchema got errors: %v, want 2 schema mismatches", errs)
+	}
+}
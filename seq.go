@@ -0,0 +1,90 @@
+//go:build go1.23
+
+package iter
+
+import stditer "iter"
+
+// Seq returns a single-use sequence of the items of the Iterable, so that
+// the Iterator can be traversed by a range-over-func loop or passed to the
+// APIs of the standard library taking an iter.Seq, e.g. slices.Collect.
+//
+// Same as Each, the Iterable is rewinded after the loop if it is a
+// Rewinder, even when the loop breaks early.
+//
+// Example:
+//   for v := range it.Seq() {
+//      fmt.Println(v)
+//   }
+func (it *Iter) Seq() stditer.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		if !it.impl.guard() {
+			return
+		}
+		defer it.impl.rewind()
+		for {
+			elm, more := it.impl.next()
+			if !more || !yield(elm) {
+				return
+			}
+		}
+	}
+}
+
+// Seq2 is like Seq but yields the index of every item as well.
+//
+// Example:
+//   for i, v := range it.Seq2() {
+//      fmt.Println(i, v)
+//   }
+func (it *Iter) Seq2() stditer.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		i := 0
+		for v := range it.Seq() {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// FromSeq creates an Iterable of the items of seq, read lazily, e.g. to
+// build a pipeline from maps.Keys or any other iter.Seq.
+//
+// The Iterable can only be traversed once and doesn't accept Add. Its
+// New API creates an in-memory Iterable for the outcome of the adapters.
+// It is an io.Closer: a sequence which isn't read until its end must be
+// closed to release its resources.
+//
+// Example:
+//   src := FromSeq(maps.Keys(m))
+//   defer src.(io.Closer).Close()
+//   New(src).Filter(isValid)
+func FromSeq(seq stditer.Seq[interface{}]) Iterable {
+	next, stop := stditer.Pull(seq)
+	return &seqSource{next: next, stop: stop}
+}
+
+// seqSource is the Iterable behind FromSeq.
+type seqSource struct {
+	next func() (interface{}, bool)
+	stop func()
+}
+
+func (*seqSource) New() (Iterable, error) {
+	return newElems()
+}
+
+func (*seqSource) Add(interface{}) {
+	panic("iter: Add is not supported by a sequence Iterable")
+}
+
+func (s *seqSource) Next() (interface{}, bool) {
+	return s.next()
+}
+
+// Close stops the sequence.
+func (s *seqSource) Close() error {
+	s.stop()
+	return nil
+}
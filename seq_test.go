@@ -0,0 +1,53 @@
+//go:build go1.23
+
+package iter
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestSeq(t *testing.T) {
+	it := New(FromStrings([]string{"a", "b", "c"}))
+	if got := slices.Collect(it.Seq()); fmt.Sprint(got) != "[a b c]" {
+		t.Errorf("Seq got: %v, want: [a b c]", got)
+	}
+
+	var got []string
+	for i, v := range it.Seq2() {
+		if i == 2 {
+			break
+		}
+		got = append(got, fmt.Sprint(i, v))
+	}
+	if fmt.Sprint(got) != "[0a 1b]" {
+		t.Errorf("Seq2 got: %v, want: [0a 1b]", got)
+	}
+	if n := it.Count(); n != 3 {
+		t.Errorf("Count after a break out of Seq2 got: %d, want: 3", n)
+	}
+}
+
+func TestFromSeq(t *testing.T) {
+	src := FromSeq(slices.Values([]interface{}{1, 2, 3}))
+	got := New(src).Filter(func(v interface{}) bool { return v.(int) != 2 }).Collect()
+	if fmt.Sprint(got) != "[1 3]" {
+		t.Errorf("FromSeq got: %v, want: [1 3]", got)
+	}
+
+	stopped := false
+	src = FromSeq(func(yield func(interface{}) bool) {
+		defer func() { stopped = true }()
+		for i := 0; yield(i); i++ {
+		}
+	})
+	if v, more := src.Next(); !more || v != 0 {
+		t.Errorf("FromSeq got: %v, %v, want: 0, true", v, more)
+	}
+	src.(io.Closer).Close()
+	if !stopped {
+		t.Error("FromSeq Close didn't stop the sequence")
+	}
+}
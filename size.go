@@ -0,0 +1,29 @@
+package iter
+
+// SizeHint returns the lower and the upper bounds of the number of items
+// the Iterable yields when traversed, without traversing it. A negative
+// upper bound means unknown. The bounds come from the SizeHinter or the
+// Lener implementation of the Iterable, if any, so they are exact for
+// the Iterables materialized by the adapters, e.g. Map.
+//
+// The Iterators returned by Pipeline.Run propagate the bounds of their
+// source: Map, Every and Or preserve them, Filter only keeps the upper
+// bound.
+//
+// Example:
+//   it := NewPipeline().Map(f).Filter(g).Run(FromStrings([]string{"a", "b"}))
+//   it.SizeHint() => 0, 2
+func (it *Iter) SizeHint() (int, int) {
+	return sizeHintOf(it.impl.item)
+}
+
+func sizeHintOf(item Iterable) (int, int) {
+	switch sized := item.(type) {
+	case SizeHinter:
+		return sized.SizeHint()
+	case Lener:
+		n := sized.Len()
+		return n, n
+	}
+	return 0, -1
+}
@@ -0,0 +1,41 @@
+package iter
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSizeHint(t *testing.T) {
+	id := func(v interface{}) interface{} { return v }
+	keep := func(interface{}) bool { return true }
+	in := []string{"a", "b", "c"}
+
+	tests := []struct {
+		desc         string
+		it           *Iter
+		lower, upper int
+	}{
+		{"strings", New(FromStrings(in)), 3, 3},
+		{"map", New(FromStrings(in)).Map(id), 3, 3},
+		{"lazy-map", NewPipeline().Map(id).Every(func(_ int, v interface{}) interface{} { return v }).Run(FromStrings(in)), 3, 3},
+		{"lazy-filter", NewPipeline().Map(id).Filter(keep).Or(keep, "").Run(FromStrings(in)), 0, 3},
+		{"lazy-when", NewPipeline().WhenFunc(keep, NewPipeline().Map(id)).Run(FromStrings(in)), 3, 3},
+		{"lazy-bind", NewPipeline().Bind(func(Params) *Pipeline { return NewPipeline() }).Run(FromStrings(in)), 0, -1},
+		{"lazy-nested", NewPipeline().Filter(keep).Run(NewPipeline().Map(id).Run(FromStrings(in)).Iterable()), 0, 3},
+		{"unknown", New(oneShot{FromStrings(in)}), 0, -1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if lower, upper := tc.it.SizeHint(); lower != tc.lower || upper != tc.upper {
+				t.Errorf("SizeHint got: %d, %d, want: %d, %d", lower, upper, tc.lower, tc.upper)
+			}
+		})
+	}
+
+	var out []string
+	NewPipeline().Map(id).Run(FromStrings(in)).CollectInto(&out)
+	if fmt.Sprint(out) != "[a b c]" || cap(out) != 3 {
+		t.Errorf("CollectInto got: %v with capacity %d, want: [a b c] with capacity 3", out, cap(out))
+	}
+}
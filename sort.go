@@ -0,0 +1,173 @@
+package iter
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// SortKey describes one key of a multi-key sort, see SortByKeys.
+type SortKey struct {
+	// Key extracts the key from an item. A nil Key uses the item itself.
+	Key func(interface{}) interface{}
+	// Desc sorts the key in descending order.
+	Desc bool
+	// Compare returns a negative number, zero or a positive number when
+	// a is less than, equal to or greater than b. A nil Compare can
+	// handle keys of string, time.Time and Go's builtin numeric types.
+	Compare func(a, b interface{}) int
+}
+
+// SortBy sorts the items of the Iterable by less and returns a new
+// Iterator contains the sorted items. The sort is stable, see SortByKeys
+// for sorting by several keys.
+//
+// Example:
+//   it := New(FromStrings([]string{"bb", "a", "ccc"}))
+//   newit := it.SortBy(func(a, b interface{}) bool {
+//      return len(a.(string)) < len(b.(string))
+//   })
+//   produces a newit contains []string{"a", "bb", "ccc"}
+func (it *Iter) SortBy(less func(a, b interface{}) bool) *Iter {
+	return newFromImpl(it.impl.sortBy(less))
+}
+
+func (it *iter) sortBy(less func(a, b interface{}) bool) *iter {
+	items := it.slice()
+	sort.SliceStable(items, func(i, j int) bool {
+		return less(items[i], items[j])
+	})
+	return it.derive("SortBy", it.fill(items), len(items), len(items))
+}
+
+// SortByKeys sorts the items of the Iterable and returns a new Iterator
+// contains the sorted items.
+//
+// The keys are applied in order: two items are ordered by the first key
+// which differs. Items which are equal by all the keys keep their
+// original order, in other words, the sort is stable.
+//
+// Example:
+//   it := New(FromStrings([]string{"b1", "a2", "b3", "a4"}))
+//   newit := it.SortByKeys(
+//      SortKey{Key: func(v interface{}) interface{} { return v.(string)[:1] }, Desc: true},
+//      SortKey{Key: func(v interface{}) interface{} { return v.(string)[1:] }})
+//   produces a newit contains []string{"b1", "b3", "a2", "a4"}
+func (it *Iter) SortByKeys(keys ...SortKey) *Iter {
+	return newFromImpl(it.impl.sortByKeys(keys))
+}
+
+func (it *iter) sortByKeys(keys []SortKey) *iter {
+	items := it.slice()
+	sort.SliceStable(items, func(i, j int) bool {
+		for _, k := range keys {
+			a, b := items[i], items[j]
+			if k.Key != nil {
+				a, b = k.Key(a), k.Key(b)
+			}
+			cmp := k.Compare
+			if cmp == nil {
+				cmp = compare
+			}
+			c := cmp(a, b)
+			if k.Desc {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+
+	return it.derive("SortByKeys", it.fill(items), len(items), len(items))
+}
+
+// fill creates a new Iterable of the same type with items.
+func (it *iter) fill(items []interface{}) Iterable {
+	newitem := it.newItem()
+	for _, v := range items {
+		newitem.Add(v)
+	}
+	return newitem
+}
+
+// BinarySearch looks for target in the Iterable which is sorted in
+// ascending order by cmp. The int is the (0-based) index of the found
+// item, or the index where target would be inserted when the bool is
+// false.
+//
+// cmp returns a negative number, zero or a positive number when an item
+// is less than, equal to or greater than target.
+//
+// If the underlying Iterable implements both Lener and Seeker, the search
+// takes O(log n) steps, otherwise BinarySearch falls back to a linear scan
+// which stops as soon as an item isn't less than target.
+// Same as other read-only APIs, a Rewinder is rewinded afterwards.
+//
+// Example:
+//   it := New(FromStrings([]string{"a", "c", "e"}))
+//   cmp := func(a, b interface{}) int { return strings.Compare(a.(string), b.(string)) }
+//   it.BinarySearch("c", cmp) => 1, true
+//   it.BinarySearch("d", cmp) => 2, false
+func (it *Iter) BinarySearch(target interface{}, cmp func(a, b interface{}) int) (int, bool) {
+	if !it.impl.guard() {
+		return 0, false
+	}
+	return it.impl.binarySearch(target, cmp)
+}
+
+func (it *iter) binarySearch(target interface{}, cmp func(a, b interface{}) int) (int, bool) {
+	defer it.rewind()
+
+	l, lok := it.item.(Lener)
+	sk, sok := it.item.(Seeker)
+	if !lok || !sok {
+		for i := 0; ; i++ {
+			v, more := it.next()
+			if !more {
+				return i, false
+			}
+			if c := cmp(v, target); c >= 0 {
+				return i, c == 0
+			}
+		}
+	}
+
+	i := sort.Search(l.Len(), func(i int) bool {
+		sk.Seek(i)
+		v, _ := it.next()
+		return cmp(v, target) >= 0
+	})
+	if i == l.Len() {
+		return i, false
+	}
+	sk.Seek(i)
+	v, _ := it.next()
+	return i, cmp(v, target) == 0
+}
+
+// compare orders two strings, two time.Time or two numbers,
+// anything else panics.
+func compare(a, b interface{}) int {
+	switch x := a.(type) {
+	case string:
+		return strings.Compare(x, b.(string))
+	case time.Time:
+		y := b.(time.Time)
+		switch {
+		case x.Before(y):
+			return -1
+		case x.After(y):
+			return 1
+		}
+		return 0
+	}
+	switch x, y := asFloat(a), asFloat(b); {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
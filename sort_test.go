@@ -0,0 +1,84 @@
+package iter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSortByKeys(t *testing.T) {
+	status := func(v interface{}) interface{} { return v.(string)[:1] }
+	stamp := func(v interface{}) interface{} { return len(v.(string)) }
+	s := []string{"b1", "a22", "b333", "a4", "b55"}
+
+	tests := []struct {
+		desc string
+		keys []SortKey
+		want string
+	}{
+		{"none", nil, "[b1 a22 b333 a4 b55]"},
+		{"self", []SortKey{{}}, "[a22 a4 b1 b333 b55]"},
+		{"desc-then-asc", []SortKey{{Key: status, Desc: true}, {Key: stamp}}, "[b1 b55 b333 a4 a22]"},
+		{"stable", []SortKey{{Key: status}}, "[a22 a4 b1 b333 b55]"},
+		{"custom", []SortKey{{Key: stamp, Compare: func(a, b interface{}) int { return b.(int) - a.(int) }}},
+			"[b333 a22 b55 b1 a4]"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := New(FromStrings(s)).SortByKeys(tc.keys...).Collect()
+			if fmt.Sprint(got) != tc.want {
+				t.Errorf("SortByKeys got: %v, want: %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	byLen := func(a, b interface{}) bool { return len(a.(string)) < len(b.(string)) }
+	got := New(FromStrings([]string{"bb", "a", "cc", "ddd", "e"})).SortBy(byLen).Collect()
+	if fmt.Sprint(got) != "[a e bb cc ddd]" {
+		t.Errorf("SortBy got: %v, want: [a e bb cc ddd]", got)
+	}
+
+	got = New(FromStrings([]string{"b", "a", "b", "a"})).SortBy(func(a, b interface{}) bool {
+		return a.(string) < b.(string)
+	}).Dedup().Collect()
+	if fmt.Sprint(got) != "[a b]" {
+		t.Errorf("SortBy then Dedup got: %v, want: [a b]", got)
+	}
+}
+
+func TestBinarySearch(t *testing.T) {
+	cmp := func(a, b interface{}) int { return strings.Compare(a.(string), b.(string)) }
+	s := []string{"a", "c", "e", "g"}
+
+	tests := []struct {
+		target string
+		idx    int
+		found  bool
+	}{
+		{"a", 0, true},
+		{"e", 2, true},
+		{"g", 3, true},
+		{"0", 0, false},
+		{"d", 2, false},
+		{"z", 4, false},
+	}
+
+	for _, tc := range tests {
+		for _, src := range []Iterable{FromStrings(s), oneShot{FromStrings(s)}} {
+			idx, found := New(src).BinarySearch(tc.target, cmp)
+			if idx != tc.idx || found != tc.found {
+				t.Errorf("BinarySearch(%q) on %T got: %d, %t, want: %d, %t",
+					tc.target, src, idx, found, tc.idx, tc.found)
+			}
+		}
+	}
+
+	it := New(FromStrings(s))
+	it.BinarySearch("e", cmp)
+	if v := it.Nth(0); v != "a" {
+		t.Errorf("BinarySearch didn't rewind the Iterable, Nth(0) got: %v", v)
+	}
+}
@@ -0,0 +1,185 @@
+// Package spec builds Pipelines of the github.com/i3d/goiter package
+// out of declarative specs, so that simple transform jobs can be
+// configured without recompiling.
+//
+// A spec lists stages by name with their arguments, e.g. in JSON:
+//
+//	{"stages": [
+//	   {"stage": "match", "args": {"pattern": "^ERROR"}},
+//	   {"stage": "replace", "args": {"pattern": "\\d+", "with": "N"}},
+//	   {"stage": "upper"}
+//	]}
+//
+// The stage names are resolved by a Registry, which comes with a few
+// builtin string stages and accepts user-defined ones through Register.
+// YAML specs can be decoded by any YAML library into a Spec, then built
+// with Registry.Build.
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	iter "github.com/i3d/goiter"
+)
+
+// Spec is a declarative Pipeline.
+type Spec struct {
+	Stages []Stage `json:"stages" yaml:"stages"`
+}
+
+// Stage is a stage of a Spec.
+type Stage struct {
+	// Name is the name of the stage in the Registry.
+	Name string `json:"stage" yaml:"stage"`
+	// Args are the arguments given to the Factory of the stage.
+	Args Args `json:"args" yaml:"args"`
+}
+
+// Args are the arguments of a Stage.
+type Args map[string]interface{}
+
+// String returns the argument name, which must be a string.
+func (a Args) String(name string) (string, error) {
+	v, ok := a[name]
+	if !ok {
+		return "", fmt.Errorf("missing argument %q", name)
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("argument %q: %v is not a string", name, v)
+	}
+	return s, nil
+}
+
+// Float returns the argument name, which must be a number.
+func (a Args) Float(name string) (float64, error) {
+	v, ok := a[name]
+	if !ok {
+		return 0, fmt.Errorf("missing argument %q", name)
+	}
+	switch f := v.(type) {
+	case float64:
+		return f, nil
+	case int:
+		return float64(f), nil
+	}
+	return 0, fmt.Errorf("argument %q: %v is not a number", name, v)
+}
+
+// Factory creates the Pipeline of a stage given its arguments.
+type Factory func(args Args) (*iter.Pipeline, error)
+
+// Registry resolves the names of the stages into Factories.
+type Registry struct {
+	factories map[string]Factory
+}
+
+// NewRegistry creates a Registry with the builtin stages, which work on
+// string items:
+//
+//	match {pattern}          keeps the items matching the regexp pattern
+//	exclude {pattern}        drops the items matching the regexp pattern
+//	replace {pattern, with}  replaces the matches of pattern with with
+//	upper, lower, trim       change the case, trim the spaces
+func NewRegistry() *Registry {
+	r := &Registry{factories: map[string]Factory{}}
+	r.Register("match", filter(true))
+	r.Register("exclude", filter(false))
+	r.Register("replace", replace)
+	r.Register("upper", mapper(strings.ToUpper))
+	r.Register("lower", mapper(strings.ToLower))
+	r.Register("trim", mapper(strings.TrimSpace))
+	return r
+}
+
+// Register adds the stage name to the Registry, replacing any stage of
+// the same name.
+func (r *Registry) Register(name string, f Factory) {
+	r.factories[name] = f
+}
+
+// Names returns the names of the registered stages, sorted.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Build creates the Pipeline of s.
+func (r *Registry) Build(s *Spec) (*iter.Pipeline, error) {
+	p := iter.NewPipeline()
+	for i, st := range s.Stages {
+		f, ok := r.factories[st.Name]
+		if !ok {
+			return nil, fmt.Errorf("spec: stage %d: unknown stage %q", i, st.Name)
+		}
+		sub, err := f(st.Args)
+		if err != nil {
+			return nil, fmt.Errorf("spec: stage %d (%s): %w", i, st.Name, err)
+		}
+		p = p.Then(sub)
+	}
+	return p, nil
+}
+
+// Load decodes a JSON Spec from rd and builds its Pipeline.
+// Unknown fields are rejected to catch typos.
+func (r *Registry) Load(rd io.Reader) (*iter.Pipeline, error) {
+	dec := json.NewDecoder(rd)
+	dec.DisallowUnknownFields()
+	var s Spec
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("spec: %w", err)
+	}
+	return r.Build(&s)
+}
+
+func filter(keep bool) Factory {
+	return func(args Args) (*iter.Pipeline, error) {
+		re, err := pattern(args)
+		if err != nil {
+			return nil, err
+		}
+		return iter.NewPipeline().Filter(func(v interface{}) bool {
+			return re.MatchString(fmt.Sprint(v)) == keep
+		}), nil
+	}
+}
+
+func replace(args Args) (*iter.Pipeline, error) {
+	re, err := pattern(args)
+	if err != nil {
+		return nil, err
+	}
+	with, err := args.String("with")
+	if err != nil {
+		return nil, err
+	}
+	return iter.NewPipeline().Map(func(v interface{}) interface{} {
+		return re.ReplaceAllString(fmt.Sprint(v), with)
+	}), nil
+}
+
+func mapper(f func(string) string) Factory {
+	return func(Args) (*iter.Pipeline, error) {
+		return iter.NewPipeline().Map(func(v interface{}) interface{} {
+			return f(fmt.Sprint(v))
+		}), nil
+	}
+}
+
+func pattern(args Args) (*regexp.Regexp, error) {
+	s, err := args.String("pattern")
+	if err != nil {
+		return nil, err
+	}
+	return regexp.Compile(s)
+}
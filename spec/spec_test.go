@@ -0,0 +1,48 @@
+package spec
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	iter "github.com/i3d/goiter"
+)
+
+func TestLoad(t *testing.T) {
+	r := NewRegistry()
+	r.Register("suffix", func(args Args) (*iter.Pipeline, error) {
+		s, err := args.String("with")
+		if err != nil {
+			return nil, err
+		}
+		return iter.NewPipeline().Map(func(v interface{}) interface{} { return v.(string) + s }), nil
+	})
+
+	p, err := r.Load(strings.NewReader(`{"stages": [
+		{"stage": "match", "args": {"pattern": "^ERROR"}},
+		{"stage": "replace", "args": {"pattern": "\\d+", "with": "N"}},
+		{"stage": "lower"},
+		{"stage": "suffix", "args": {"with": "!"}}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := iter.FromStrings([]string{"ERROR 42", "INFO 1", "ERROR x7"})
+	if got := p.Run(src).Collect(); fmt.Sprint(got) != "[error n! error xn!]" {
+		t.Errorf("loaded Pipeline got: %v, want: [error n! error xn!]", got)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	r := NewRegistry()
+	for spec, want := range map[string]string{
+		`{"stages": [{"stage": "nope"}]}`:                          `spec: stage 0: unknown stage "nope"`,
+		`{"stages": [{"stage": "upper"}, {"stage": "match"}]}`:     `spec: stage 1 (match): missing argument "pattern"`,
+		`{"stages": [{"stage": "match", "args": {"pattern": 1}}]}`: `spec: stage 0 (match): argument "pattern": 1 is not a string`,
+		`{"stages": [{"stage": "upper", "argz": {}}]}`:             `spec: json: unknown field "argz"`,
+	} {
+		if _, err := r.Load(strings.NewReader(spec)); err == nil || err.Error() != want {
+			t.Errorf("Load(%s) got error: %v, want: %s", spec, err, want)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Package sqlio writes the items of an Iterator of the
+// github.com/i3d/goiter package into SQL databases.
+package sqlio
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	iter "github.com/i3d/goiter"
+)
+
+// InsertOptions configures Insert.
+type InsertOptions struct {
+	// Table is the table to insert into.
+	Table string
+	// Columns are the columns to insert, in the order of the values.
+	Columns []string
+	// BatchSize is the number of rows per INSERT statement,
+	// 100 if not positive.
+	BatchSize int
+	// TxSize is the number of INSERT statements per transaction.
+	// If not positive, all of them run in a single transaction.
+	TxSize int
+	// Placeholder returns the placeholder of the (1-based) n-th
+	// parameter of a statement, "?" if nil. E.g. PostgreSQL needs
+	// func(n int) string { return fmt.Sprintf("$%d", n) }.
+	Placeholder func(n int) string
+}
+
+// Insert drains it into the table of opts with parameterized batch
+// INSERTs and returns the number of rows committed.
+//
+// values returns the values of an item, one per column of opts. The
+// INSERTs run in transactions of opts.TxSize statements, a failing
+// statement rolls back its transaction and stops Insert, so the rows
+// committed by the previous transactions stay, e.g. to resume from.
+//
+// Example:
+//   n, err := sqlio.Insert(ctx, db, it, sqlio.InsertOptions{
+//      Table:   "people",
+//      Columns: []string{"name", "age"},
+//   }, func(v interface{}) []interface{} {
+//      p := v.(*Person)
+//      return []interface{}{p.Name, p.Age}
+//   })
+func Insert(ctx context.Context, db *sql.DB, it *iter.Iter, opts InsertOptions, values func(interface{}) []interface{}) (int64, error) {
+	if opts.Table == "" || len(opts.Columns) == 0 {
+		return 0, fmt.Errorf("sqlio: Insert needs a table and columns")
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.Placeholder == nil {
+		opts.Placeholder = func(int) string { return "?" }
+	}
+
+	w := &inserter{ctx: ctx, db: db, opts: opts}
+	it.Each(func(v interface{}) {
+		if w.err != nil {
+			return
+		}
+		vs := values(v)
+		if len(vs) != len(opts.Columns) {
+			w.fail(fmt.Errorf("sqlio: %d values for %d columns in item %+v", len(vs), len(opts.Columns), v))
+			return
+		}
+		w.args = append(w.args, vs...)
+		w.rows++
+		if w.rows == opts.BatchSize {
+			w.flush()
+		}
+	})
+	if w.rows > 0 {
+		w.flush()
+	}
+	w.commit()
+	return w.committed, w.err
+}
+
+// inserter buffers the rows of an INSERT and runs the statements
+// in transactions. The first error stops everything.
+type inserter struct {
+	ctx  context.Context
+	db   *sql.DB
+	opts InsertOptions
+
+	tx        *sql.Tx
+	stmts     int
+	args      []interface{}
+	rows      int
+	pending   int64
+	committed int64
+	err       error
+}
+
+func (w *inserter) flush() {
+	if w.err != nil {
+		return
+	}
+	if w.tx == nil {
+		tx, err := w.db.BeginTx(w.ctx, nil)
+		if err != nil {
+			w.fail(err)
+			return
+		}
+		w.tx = tx
+	}
+	if _, err := w.tx.ExecContext(w.ctx, w.statement(w.rows), w.args...); err != nil {
+		w.fail(err)
+		return
+	}
+	w.pending += int64(w.rows)
+	w.args, w.rows = w.args[:0], 0
+	w.stmts++
+	if w.opts.TxSize > 0 && w.stmts == w.opts.TxSize {
+		w.commit()
+	}
+}
+
+func (w *inserter) commit() {
+	if w.err != nil || w.tx == nil {
+		return
+	}
+	if err := w.tx.Commit(); err != nil {
+		w.tx = nil
+		w.fail(err)
+		return
+	}
+	w.committed += w.pending
+	w.tx, w.stmts, w.pending = nil, 0, 0
+}
+
+func (w *inserter) fail(err error) {
+	if w.tx != nil {
+		w.tx.Rollback()
+		w.tx = nil
+	}
+	w.err = err
+}
+
+// statement builds the INSERT of rows rows.
+func (w *inserter) statement(rows int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "INSERT INTO %s (%s) VALUES ", w.opts.Table, strings.Join(w.opts.Columns, ", "))
+	n := 0
+	for r := 0; r < rows; r++ {
+		if r > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteByte('(')
+		for c := range w.opts.Columns {
+			if c > 0 {
+				b.WriteString(", ")
+			}
+			n++
+			b.WriteString(w.opts.Placeholder(n))
+		}
+		b.WriteByte(')')
+	}
+	return b.String()
+}
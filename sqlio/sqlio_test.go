@@ -0,0 +1,108 @@
+package sqlio
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	iter "github.com/i3d/goiter"
+)
+
+// recorder is a database/sql driver logging the statements and
+// transactions it runs. A statement containing "fail" fails.
+type recorder struct {
+	log []string
+}
+
+func (r *recorder) Open(string) (driver.Conn, error) { return r, nil }
+func (r *recorder) Close() error                     { return nil }
+func (r *recorder) Begin() (driver.Tx, error) {
+	r.log = append(r.log, "BEGIN")
+	return r, nil
+}
+func (r *recorder) Commit() error {
+	r.log = append(r.log, "COMMIT")
+	return nil
+}
+func (r *recorder) Rollback() error {
+	r.log = append(r.log, "ROLLBACK")
+	return nil
+}
+func (r *recorder) Prepare(query string) (driver.Stmt, error) {
+	return &stmt{r, query}, nil
+}
+
+type stmt struct {
+	r     *recorder
+	query string
+}
+
+func (s *stmt) Close() error  { return nil }
+func (s *stmt) NumInput() int { return -1 }
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.r.log = append(s.r.log, fmt.Sprint(s.query, " ", args))
+	for _, a := range args {
+		if a == "fail" {
+			return nil, errors.New("failed")
+		}
+	}
+	return driver.RowsAffected(0), nil
+}
+func (s *stmt) Query([]driver.Value) (driver.Rows, error) { return nil, errors.New("no query") }
+
+func open(t *testing.T) (*sql.DB, *recorder) {
+	r := &recorder{}
+	name := "recorder-" + t.Name()
+	sql.Register(name, r)
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, r
+}
+
+func TestInsert(t *testing.T) {
+	db, r := open(t)
+	defer db.Close()
+
+	it := iter.New(iter.FromStrings([]string{"a", "b", "c"}))
+	n, err := Insert(context.Background(), db, it, InsertOptions{
+		Table:     "t",
+		Columns:   []string{"s", "n"},
+		BatchSize: 2,
+		TxSize:    1,
+	}, func(v interface{}) []interface{} {
+		return []interface{}{v, len(v.(string))}
+	})
+	want := []string{
+		"BEGIN", "INSERT INTO t (s, n) VALUES (?, ?), (?, ?) [a 1 b 1]", "COMMIT",
+		"BEGIN", "INSERT INTO t (s, n) VALUES (?, ?) [c 1]", "COMMIT",
+	}
+	if n != 3 || err != nil || strings.Join(r.log, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Insert got %d rows, error: %v, ran:\n%s", n, err, strings.Join(r.log, "\n"))
+	}
+}
+
+func TestInsertFailure(t *testing.T) {
+	db, r := open(t)
+	defer db.Close()
+
+	it := iter.New(iter.FromStrings([]string{"a", "b", "fail", "d"}))
+	n, err := Insert(context.Background(), db, it, InsertOptions{
+		Table:       "t",
+		Columns:     []string{"s"},
+		BatchSize:   1,
+		TxSize:      2,
+		Placeholder: func(n int) string { return fmt.Sprintf("$%d", n) },
+	}, func(v interface{}) []interface{} { return []interface{}{v} })
+	if n != 2 || err == nil {
+		t.Errorf("Insert got %d rows, error: %v, want 2 rows and an error", n, err)
+	}
+	if last := r.log[len(r.log)-1]; last != "ROLLBACK" || !strings.Contains(r.log[1], "VALUES ($1)") {
+		t.Errorf("Insert ran:\n%s", strings.Join(r.log, "\n"))
+	}
+}
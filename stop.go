@@ -0,0 +1,81 @@
+package iter
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// Until returns a new Iterator over the same Iterable which reports no
+// more items once ctx is done, so that a long-running terminal, e.g. Each
+// over a never-ending source, stops cleanly between two items and returns
+// as if the Iterable ended, flushing whatever it writes to.
+//
+// The returned Iterator is lazy: it reads the Iterable as it is read.
+// Same as Pipe, it starts a fresh Report. Its Iterable is a Rewinder only
+// if the Iterable is one.
+//
+// Example:
+//   ctx, cancel := context.WithTimeout(ctx, time.Minute)
+//   defer cancel()
+//   n, err := New(src).Until(ctx).PostJSON(ctx, url, PostOptions{})
+func (it *Iter) Until(ctx context.Context) *Iter {
+	s := &stoppable{src: it.impl.item, ctx: ctx}
+	if _, ok := s.src.(Rewinder); ok {
+		return New(rewindableStoppable{s})
+	}
+	return New(s)
+}
+
+// StopOn is like Until but stops once the process receives one of the
+// signals, os.Interrupt and syscall.SIGTERM if none is given, e.g. for a
+// CLI tool to stop on Ctrl-C. The returned stop function stops relaying
+// the signals, it has to be called once the Iterator is done.
+//
+// Example:
+//   it, stop := New(followed).StopOn()
+//   defer stop()
+//   err := fileio.WriteLines(it.Filter(isError), "errors.log")
+func (it *Iter) StopOn(signals ...os.Signal) (*Iter, func()) {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), signals...)
+	return it.Until(ctx), stop
+}
+
+// stoppable is the Iterable behind Until.
+type stoppable struct {
+	src Iterable
+	ctx context.Context
+}
+
+// Err returns the error of the source, if any.
+func (s *stoppable) Err() error {
+	return errOf(s.src)
+}
+
+func (s *stoppable) New() (Iterable, error) {
+	return s.src.New()
+}
+
+func (*stoppable) Add(interface{}) {
+	panic("iter: Add is not supported by a stoppable Iterable")
+}
+
+func (s *stoppable) Next() (interface{}, bool) {
+	if s.ctx.Err() != nil {
+		return nil, false
+	}
+	return s.src.Next()
+}
+
+// rewindableStoppable is a stoppable over a Rewinder.
+type rewindableStoppable struct {
+	*stoppable
+}
+
+func (s rewindableStoppable) Rewind() {
+	s.src.(Rewinder).Rewind()
+}
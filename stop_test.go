@@ -0,0 +1,60 @@
+package iter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestUntil(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var got []interface{}
+	New(FromStrings([]string{"a", "b", "c"})).Until(ctx).Each(func(v interface{}) {
+		got = append(got, v)
+		if v == "b" {
+			cancel()
+		}
+	})
+	if len(got) != 2 {
+		t.Errorf("Until got: %v, want: [a b]", got)
+	}
+
+	once := New(oneShot{FromInts([]int{0, 5, 10})}).Until(context.Background())
+	if once.Capabilities().Rewinder {
+		t.Error("Until over a one-shot source is a Rewinder")
+	}
+	if got := fmt.Sprint(once.Normalize(MinMax).Collect()); got != "[0 1 1]" {
+		t.Errorf("Normalize after Until over a one-shot source got: %s, want: [0 1 1]", got)
+	}
+	if !New(FromInts(nil)).Until(context.Background()).Capabilities().Rewinder {
+		t.Error("Until over a Rewinder isn't a Rewinder")
+	}
+}
+
+func TestStopOn(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("os.Interrupt can't be sent on windows")
+	}
+	it, stop := New(FromStrings([]string{"a", "b", "c"})).StopOn(os.Interrupt)
+	defer stop()
+
+	var got []interface{}
+	it.Each(func(v interface{}) {
+		got = append(got, v)
+		if v == "a" {
+			p, _ := os.FindProcess(os.Getpid())
+			p.Signal(os.Interrupt)
+			// Wait for the signal to be relayed.
+			for it.impl.item.(rewindableStoppable).ctx.Err() == nil {
+				runtime.Gosched()
+			}
+		}
+	})
+	if len(got) != 1 {
+		t.Errorf("StopOn got: %v, want: [a]", got)
+	}
+}
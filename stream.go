@@ -0,0 +1,447 @@
+package iter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+
+	"github.com/i3d/goiter/internal/command"
+)
+
+// Reader returns an io.Reader streaming the items of the Iterable, each
+// one encoded by format, e.g. to feed an io based API such as an HTTP
+// request body or a gzip.Writer with a pipeline output. Items are read
+// lazily, as the returned Reader is read. A nil format encodes nothing.
+//
+// The Iterable is consumed and not rewinded.
+//
+// Example:
+//   r := it.Reader(func(v interface{}) []byte { return []byte(v.(string) + "\n") })
+//   http.Post(url, "text/plain", r)
+func (it *Iter) Reader(format func(interface{}) []byte) io.Reader {
+	return &itemReader{it: it.impl, format: format}
+}
+
+// itemReader is the io.Reader behind Reader.
+type itemReader struct {
+	it     *iter
+	format func(interface{}) []byte
+	buf    []byte
+}
+
+func (r *itemReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		v, more := r.it.next()
+		if !more {
+			return 0, io.EOF
+		}
+		if r.format != nil {
+			r.buf = r.format(v)
+		}
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// ErrClosed is returned by a WriterSource written after Close.
+var ErrClosed = errors.New("iter: write to a closed WriterSource")
+
+// WriterSource is an io.WriteCloser whose written chunks become the
+// items of an Iterable, as []byte, so that any io based API can feed an
+// Iterator, e.g. io.Copy or an exec.Cmd's Stdout.
+//
+// Next waits for a chunk until the WriterSource is closed, so the writes
+// and the traversal can happen on different goroutines, or the chunks can
+// be written and the WriterSource closed before the traversal.
+// WriterSource is thread-safe.
+//
+// Example:
+//   ws := NewWriterSource()
+//   go func() {
+//      io.Copy(ws, conn)
+//      ws.Close()
+//   }()
+//   New(ws).Each(handle)
+type WriterSource struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	chunks []interface{}
+	closed bool
+}
+
+// NewWriterSource creates a new empty WriterSource.
+func NewWriterSource() *WriterSource {
+	ws := &WriterSource{}
+	ws.cond = sync.NewCond(&ws.mu)
+	return ws
+}
+
+// New constructs a new empty in-memory Iterable hosting the outcome of
+// the adapters, which unlike a WriterSource doesn't wait to be closed.
+func (*WriterSource) New() (Iterable, error) {
+	return newElems()
+}
+
+// Add pushes an item as if it were written.
+func (ws *WriterSource) Add(obj interface{}) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.chunks = append(ws.chunks, obj)
+	ws.cond.Signal()
+}
+
+// Write implements io.Writer for WriterSource, p is copied as one item.
+func (ws *WriterSource) Write(p []byte) (int, error) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.closed {
+		return 0, ErrClosed
+	}
+	ws.chunks = append(ws.chunks, append([]byte(nil), p...))
+	ws.cond.Signal()
+	return len(p), nil
+}
+
+// Close implements io.Closer for WriterSource, the traversal ends once
+// the chunks written so far are read.
+func (ws *WriterSource) Close() error {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.closed = true
+	ws.cond.Broadcast()
+	return nil
+}
+
+// Next returns the next chunk, waiting for one if needed.
+// bool indicates whether the WriterSource is closed and drained.
+func (ws *WriterSource) Next() (interface{}, bool) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for len(ws.chunks) == 0 {
+		if ws.closed {
+			return nil, false
+		}
+		ws.cond.Wait()
+	}
+	v := ws.chunks[0]
+	ws.chunks = ws.chunks[1:]
+	return v, true
+}
+
+// FromChan creates an Iterable of the items received from ch, so that a
+// goroutine can feed an Iterator. Next waits for an item and reports no
+// more items once ch is closed and drained.
+//
+// The Iterable can only be traversed once and doesn't accept Add. Its
+// New API creates an in-memory Iterable for the outcome of the adapters.
+//
+// Example:
+//   ch := make(chan interface{})
+//   go produce(ch) // closes ch when done
+//   New(FromChan(ch)).Each(handle)
+func FromChan(ch <-chan interface{}) Iterable {
+	return chanSource(ch)
+}
+
+// chanSource is the Iterable behind FromChan.
+type chanSource <-chan interface{}
+
+func (chanSource) New() (Iterable, error) {
+	return newElems()
+}
+
+func (chanSource) Add(interface{}) {
+	panic("iter: Add is not supported by a channel Iterable")
+}
+
+func (c chanSource) Next() (interface{}, bool) {
+	v, ok := <-c
+	return v, ok
+}
+
+// ToChan streams the items of the Iterable into the returned channel,
+// which buffers up to buf items, from a new goroutine, so that a pipeline
+// can feed channel based code. The channel is closed once every item is
+// sent or once ctx is done, the remaining items are then left unread.
+//
+// The Iterator must not be used until the channel is closed. Same as
+// Each, the Iterable is then rewinded if it is a Rewinder.
+//
+// Example:
+//   for v := range it.ToChan(ctx, 16) {
+//      handle(v)
+//   }
+func (it *Iter) ToChan(ctx context.Context, buf int) <-chan interface{} {
+	ch := make(chan interface{}, buf)
+	if !it.impl.guard() {
+		close(ch)
+		return ch
+	}
+	go func() {
+		defer close(ch)
+		defer it.impl.rewind()
+		for {
+			elm, more := it.impl.next()
+			if !more {
+				return
+			}
+			select {
+			case ch <- elm:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// Pull returns the items of the Iterable one at a time, like the
+// standard library's iter.Pull, so that the consumer drives the traversal,
+// e.g. to interleave two Iterators. next returns the next item, the bool
+// indicates whether there is one. stop ends the traversal early, next
+// reports no more items afterwards. stop may be called more than once.
+//
+// Same as Each, the Iterable is rewinded by stop if it is a Rewinder.
+//
+// Example:
+//   next, stop := it.Pull()
+//   defer stop()
+//   for v, ok := next(); ok; v, ok = next() {
+//      ...
+//   }
+func (it *Iter) Pull() (next func() (interface{}, bool), stop func()) {
+	done := !it.impl.guard()
+	next = func() (interface{}, bool) {
+		if done {
+			return nil, false
+		}
+		return it.impl.next()
+	}
+	stop = func() {
+		if !done {
+			done = true
+			it.impl.rewind()
+		}
+	}
+	return next, stop
+}
+
+// FromReaderLines creates a lazy Iterable of the lines read from r, as
+// strings without their line terminator, so that a huge input flows
+// through the adapters without being loaded in memory first. A line
+// longer than bufio.MaxScanTokenSize stops the Iterable with an error.
+//
+// The Iterable can only be traversed once and doesn't accept Add. Its
+// New API creates an in-memory Iterable for the outcome of the adapters.
+// The read error, if any, is returned by Err of the Iterator reading it.
+//
+// Example:
+//   it := New(FromReaderLines(os.Stdin))
+//   errs := it.Filter(func(v interface{}) bool { return strings.Contains(v.(string), "ERROR") })
+//   if err := it.Err(); err != nil {
+//      return err
+//   }
+func FromReaderLines(r io.Reader) Iterable {
+	return &lineSource{sc: bufio.NewScanner(r)}
+}
+
+// lineSource is the Iterable behind FromReaderLines.
+type lineSource struct {
+	sc *bufio.Scanner
+}
+
+func (*lineSource) New() (Iterable, error) {
+	return newElems()
+}
+
+func (*lineSource) Add(interface{}) {
+	panic("iter: Add is not supported by a line Iterable")
+}
+
+func (ls *lineSource) Next() (interface{}, bool) {
+	if !ls.sc.Scan() {
+		return nil, false
+	}
+	return ls.sc.Text(), true
+}
+
+// Err returns the error which stopped the scan, if any.
+func (ls *lineSource) Err() error {
+	return ls.sc.Err()
+}
+
+// FromJSONArray creates a lazy Iterable of the elements of the JSON array
+// read from r, decoded one at a time, so that a large array is never held
+// in memory as a whole. Every element is decoded into a new value created
+// by newElem, a pointer such as new(Event), which becomes the item. A nil
+// newElem decodes into an interface{}, e.g. a map[string]interface{} for
+// a JSON object, which becomes the item.
+//
+// The Iterable can only be traversed once and doesn't accept Add. Its
+// New API creates an in-memory Iterable for the outcome of the adapters.
+// The decoding error, if any, e.g. for input which isn't an array, is
+// returned by Err of the Iterator reading it.
+//
+// Example:
+//   it := New(FromJSONArray(resp.Body, func() interface{} { return new(Event) }))
+//   it.Each(func(v interface{}) { handle(v.(*Event)) })
+//   if err := it.Err(); err != nil {
+//      return err
+//   }
+func FromJSONArray(r io.Reader, newElem func() interface{}) Iterable {
+	return &jsonArray{dec: json.NewDecoder(r), newElem: newElem}
+}
+
+// jsonArray is the Iterable behind FromJSONArray.
+type jsonArray struct {
+	dec     *json.Decoder
+	newElem func() interface{}
+	started bool
+	done    bool
+	err     error
+}
+
+func (*jsonArray) New() (Iterable, error) {
+	return newElems()
+}
+
+func (*jsonArray) Add(interface{}) {
+	panic("iter: Add is not supported by a JSON array Iterable")
+}
+
+func (ja *jsonArray) Next() (interface{}, bool) {
+	if ja.done {
+		return nil, false
+	}
+	if !ja.started {
+		ja.started = true
+		if err := ja.delim('['); err != nil {
+			return ja.fail(err)
+		}
+	}
+	if !ja.dec.More() {
+		ja.done = true
+		if err := ja.delim(']'); err != nil {
+			return ja.fail(err)
+		}
+		return nil, false
+	}
+
+	if ja.newElem == nil {
+		var v interface{}
+		if err := ja.dec.Decode(&v); err != nil {
+			return ja.fail(err)
+		}
+		return v, true
+	}
+	v := ja.newElem()
+	if err := ja.dec.Decode(v); err != nil {
+		return ja.fail(err)
+	}
+	return v, true
+}
+
+// delim reads the delimiter want.
+func (ja *jsonArray) delim(want json.Delim) error {
+	tok, err := ja.dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != want {
+		return fmt.Errorf("iter: JSON array expects %v, got %v", want, tok)
+	}
+	return nil
+}
+
+func (ja *jsonArray) fail(err error) (interface{}, bool) {
+	ja.done, ja.err = true, err
+	return nil, false
+}
+
+// Err returns the error which stopped the decoding, if any.
+func (ja *jsonArray) Err() error {
+	return ja.err
+}
+
+// FromCmdLines starts cmd and creates a lazy Iterable of the lines of its
+// standard output, as strings without their line terminator, e.g. to feed
+// a pipeline with the output of a CLI tool. cmd must not have its Stdout
+// set.
+//
+// Once the output ends, the command is waited for. Its exit error, e.g.
+// an *exec.ExitError for a non-zero exit status, or the error starting
+// it, is returned by Err of the Iterator reading it. The Iterable is an
+// io.Closer which kills the command, to be called when the output isn't
+// read until its end. See fileio.FromCommand for a Source stopping with
+// a context, FromCmdLines shares its implementation.
+//
+// The Iterable can only be traversed once and doesn't accept Add. Its
+// New API creates an in-memory Iterable for the outcome of the adapters.
+//
+// Example:
+//   it := New(FromCmdLines(exec.Command("git", "ls-files")))
+//   goFiles := it.Filter(func(v interface{}) bool { return strings.HasSuffix(v.(string), ".go") })
+//   if err := it.Err(); err != nil {
+//      return err
+//   }
+func FromCmdLines(cmd *exec.Cmd) Iterable {
+	c, err := command.Start(context.Background(), cmd)
+	if err != nil {
+		return &cmdLines{done: true, err: err}
+	}
+	return &cmdLines{c: c}
+}
+
+// cmdLines is the Iterable behind FromCmdLines.
+type cmdLines struct {
+	c    *command.Command
+	done bool
+	err  error
+}
+
+func (*cmdLines) New() (Iterable, error) {
+	return newElems()
+}
+
+func (*cmdLines) Add(interface{}) {
+	panic("iter: Add is not supported by a command Iterable")
+}
+
+func (cl *cmdLines) Next() (interface{}, bool) {
+	if cl.done {
+		return nil, false
+	}
+	v, err := cl.c.Read()
+	if err == nil {
+		return v, true
+	}
+	if errors.Is(err, io.EOF) {
+		err = nil
+	}
+	cl.done = true
+	if cerr := cl.c.Close(); err == nil {
+		err = cerr
+	}
+	cl.err = err
+	return nil, false
+}
+
+// Err returns the error of the command, if any, once its output ends.
+func (cl *cmdLines) Err() error {
+	return cl.err
+}
+
+// Close kills the command if its output hasn't been read until its end.
+func (cl *cmdLines) Close() error {
+	if cl.done {
+		return nil
+	}
+	cl.done = true
+	return cl.c.Close()
+}
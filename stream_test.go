@@ -0,0 +1,197 @@
+package iter
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestReader(t *testing.T) {
+	line := func(v interface{}) []byte { return []byte(v.(string) + "\n") }
+	r := New(FromStrings([]string{"a", "", "bcd"})).Reader(line)
+
+	// Read one byte at a time to cross the item boundaries.
+	var got strings.Builder
+	buf := make([]byte, 1)
+	for {
+		n, err := r.Read(buf)
+		got.Write(buf[:n])
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got.String() != "a\n\nbcd\n" {
+		t.Errorf("Reader got: %q, want: %q", got.String(), "a\n\nbcd\n")
+	}
+}
+
+func TestWriterSource(t *testing.T) {
+	ws := NewWriterSource()
+	go func() {
+		io.Copy(ws, strings.NewReader("hello"))
+		fmt.Fprint(ws, "world")
+		ws.Close()
+	}()
+
+	got := New(ws).Map(func(v interface{}) interface{} {
+		return strings.ToUpper(string(v.([]byte)))
+	}).Collect()
+	if fmt.Sprint(got) != "[HELLO WORLD]" {
+		t.Errorf("WriterSource got: %v, want: [HELLO WORLD]", got)
+	}
+	if _, err := ws.Write([]byte("late")); err != ErrClosed {
+		t.Errorf("Write after Close got error: %v, want: %v", err, ErrClosed)
+	}
+}
+
+func TestFromChan(t *testing.T) {
+	ch := make(chan interface{})
+	go func() {
+		for _, v := range []string{"a", "b", "c"} {
+			ch <- v
+		}
+		close(ch)
+	}()
+
+	it := New(FromChan(ch)).Filter(func(v interface{}) bool { return v.(string) != "b" })
+	if got := fmt.Sprint(it.Collect()); got != "[a c]" {
+		t.Errorf("FromChan got: %s, want: [a c]", got)
+	}
+	if _, more := FromChan(ch).Next(); more {
+		t.Error("FromChan of a closed channel got more items")
+	}
+}
+
+func TestToChan(t *testing.T) {
+	it := New(FromStrings([]string{"a", "b", "c"}))
+	var got []interface{}
+	for v := range it.ToChan(context.Background(), 1) {
+		got = append(got, v)
+	}
+	if fmt.Sprint(got) != "[a b c]" {
+		t.Errorf("ToChan got: %v, want: [a b c]", got)
+	}
+	if n := it.Count(); n != 3 {
+		t.Errorf("Count after ToChan got: %d, want: 3", n)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := it.ToChan(ctx, 0)
+	if v := <-ch; v != "a" {
+		t.Errorf("ToChan got: %v, want: a", v)
+	}
+	cancel()
+	for range ch {
+		// At most one item may be sent before the cancellation is seen.
+	}
+}
+
+func TestPull(t *testing.T) {
+	it := New(FromStrings([]string{"a", "b", "c"}))
+	next, stop := it.Pull()
+	if v, more := next(); !more || v != "a" {
+		t.Errorf("Pull got: %v, %v, want: a, true", v, more)
+	}
+	stop()
+	stop()
+	if v, more := next(); more {
+		t.Errorf("Pull after stop got: %v, want no more items", v)
+	}
+	if n := it.Count(); n != 3 {
+		t.Errorf("Count after Pull got: %d, want: 3", n)
+	}
+}
+
+func TestFromReaderLines(t *testing.T) {
+	it := New(FromReaderLines(strings.NewReader("a\r\nbb\n\nccc")))
+	long := it.Filter(func(v interface{}) bool { return len(v.(string)) > 1 })
+	if got := fmt.Sprint(long.Collect()); got != "[bb ccc]" || long.Err() != nil {
+		t.Errorf("FromReaderLines got: %s, %v, want: [bb ccc]", got, long.Err())
+	}
+
+	failing := func() Iterable {
+		return FromReaderLines(io.MultiReader(strings.NewReader("a\n"), iotest.ErrReader(io.ErrUnexpectedEOF)))
+	}
+	it = New(failing())
+	if n := it.Count(); n != 1 || !errors.Is(it.Err(), io.ErrUnexpectedEOF) {
+		t.Errorf("FromReaderLines on a failing reader got: %d, %v, want: 1, %v", n, it.Err(), io.ErrUnexpectedEOF)
+	}
+
+	// The Iterables wrapping the source report its error as well.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	wrapped := map[string]*Iter{
+		"Run":      NewPipeline().Filter(func(interface{}) bool { return true }).Run(failing()),
+		"Memoize":  New(failing()).Memoize(),
+		"Until":    New(failing()).Until(ctx),
+		"RunDebug": NewPipeline().RunDebug(failing(), DebugFunc(func(DebugEvent) {})),
+	}
+	for name, it := range wrapped {
+		if n := it.Count(); n != 1 || !errors.Is(it.Err(), io.ErrUnexpectedEOF) {
+			t.Errorf("%s of a failing reader got: %d, %v, want: 1, %v", name, n, it.Err(), io.ErrUnexpectedEOF)
+		}
+	}
+}
+
+func TestFromJSONArray(t *testing.T) {
+	type event struct{ ID int }
+	it := New(FromJSONArray(strings.NewReader(`[{"ID": 1}, {"ID": 2}]`), func() interface{} { return new(event) }))
+	var ids []int
+	it.Each(func(v interface{}) { ids = append(ids, v.(*event).ID) })
+	if fmt.Sprint(ids) != "[1 2]" || it.Err() != nil {
+		t.Errorf("FromJSONArray got: %v, %v, want: [1 2]", ids, it.Err())
+	}
+
+	it = New(FromJSONArray(strings.NewReader(`["a", {"b": 1}]`), nil))
+	if got := fmt.Sprint(it.Filter(func(interface{}) bool { return true }).Collect()); got != "[a map[b:1]]" {
+		t.Errorf("FromJSONArray without newElem got: %s, want: [a map[b:1]]", got)
+	}
+
+	for _, in := range []string{`{"a": 1}`, `[1, 2`, `[1, "x"]`} {
+		it := New(FromJSONArray(strings.NewReader(in), func() interface{} { return new(int) }))
+		it.Count()
+		if it.Err() == nil {
+			t.Errorf("FromJSONArray of %s got no error", in)
+		}
+	}
+}
+
+func TestFromCmdLines(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no sh:", err)
+	}
+
+	it := New(FromCmdLines(exec.Command("sh", "-c", "echo a; echo b; exit 3")))
+	got := it.Filter(func(interface{}) bool { return true }).Collect()
+	var exit *exec.ExitError
+	if fmt.Sprint(got) != "[a b]" || !errors.As(it.Err(), &exit) || exit.ExitCode() != 3 {
+		t.Errorf("FromCmdLines got: %v, %v, want: [a b] and exit status 3", got, it.Err())
+	}
+
+	it = New(FromCmdLines(exec.Command("no-such-command-for-goiter")))
+	if n := it.Count(); n != 0 || it.Err() == nil {
+		t.Errorf("FromCmdLines of a missing command got: %d, %v, want: 0 and an error", n, it.Err())
+	}
+
+	src := FromCmdLines(exec.Command("sh", "-c", "echo a; exec sleep 10"))
+	if v, more := src.Next(); !more || v != "a" {
+		t.Fatalf("FromCmdLines got: %v, %v, want: a, true", v, more)
+	}
+	if err := src.(io.Closer).Close(); err != nil {
+		t.Errorf("FromCmdLines Close got error: %v", err)
+	}
+	// The command blocked on writing a line too long to be read is killed.
+	it = New(FromCmdLines(exec.Command("sh", "-c", "head -c 200000 /dev/zero | tr '\\0' x")))
+	if n := it.Count(); n != 0 || !errors.Is(it.Err(), bufio.ErrTooLong) {
+		t.Errorf("FromCmdLines of a too long line got: %d, %v, want: 0, %v", n, it.Err(), bufio.ErrTooLong)
+	}
+}
@@ -0,0 +1,129 @@
+package iter
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The contract violations detected by an Iterator in strict mode,
+// see Strict, or under the ErrorIfConsuming RewindPolicy.
+var (
+	// ErrConsumed reports an Iterator whose Iterable is not a Rewinder
+	// being used again after the Iterable has been traversed.
+	ErrConsumed = errors.New("Iterable is consumed and is not a Rewinder")
+	// ErrNotFused reports an Iterable yielding more items after it has
+	// reported its end.
+	ErrNotFused = errors.New("Iterable yields items after its end")
+	// ErrNotEnumerator reports an API which requires an Enumerator being
+	// called on an Iterable which is not one.
+	ErrNotEnumerator = errors.New("Iterable is not an Enumerator")
+	// ErrNotRewinder reports a terminal refusing to consume an Iterable
+	// which is not a Rewinder, see ErrorIfConsuming.
+	ErrNotRewinder = errors.New("Iterable would be consumed and is not a Rewinder")
+)
+
+// MisuseError is a contract violation detected in strict mode.
+type MisuseError struct {
+	// Pos is the Position of the Iterator when the violation is detected.
+	Pos int
+	// Item is the Iterable involved.
+	Item Iterable
+	// Err is one of ErrConsumed, ErrNotFused, ErrNotEnumerator or
+	// ErrNotRewinder.
+	Err error
+}
+
+// Error implements the error interface for MisuseError.
+func (e *MisuseError) Error() string {
+	return fmt.Sprintf("iter: misuse of %T at position %d: %v", e.Item, e.Pos, e.Err)
+}
+
+// Unwrap returns the violation, so that errors.Is can tell which one it is.
+func (e *MisuseError) Unwrap() error {
+	return e.Err
+}
+
+// Strict turns on the strict mode of the Iterator and returns it.
+// The Iterators derived from a strict Iterator are strict as well.
+//
+// A strict Iterator checks the Iterable protocol is honoured while
+// reading it. Rather than producing silently wrong results or panicking,
+// a violation stops the reading as if the Iterable had no more items and
+// is recorded as a *MisuseError returned by Err. The violations are:
+//   - reading a consumed Iterable which is not a Rewinder (ErrConsumed),
+//   - an Iterable yielding items after reporting its end (ErrNotFused),
+//   - Every, First or Last on a non Enumerator (ErrNotEnumerator).
+//
+// Example:
+//   it := New(oneShotSource).Strict()
+//   it.Each(print)
+//   it.Each(print) prints nothing
+//   errors.Is(it.Err(), ErrConsumed) => true
+func (it *Iter) Strict() *Iter {
+	it.impl.strict = true
+	return it
+}
+
+// Err returns the first error met by this Iterator or by the Iterators
+// it has been derived from, nil if none. It is either a violation
+// detected in strict mode or under the ErrorIfConsuming RewindPolicy,
+// the error returned by the New API of the Iterable, in which case
+// the adapter has put its outcome into an in-memory Iterable instead of
+// panicking, or the error returned by the Err method of an Iterable
+// which has one, e.g. FromReaderLines, once it has no more items. The
+// lazy Iterables wrapping another one, e.g. the ones of Pipeline.Run and
+// Memoize, report the error of the wrapped one that way.
+func (it *Iter) Err() error {
+	return it.impl.err
+}
+
+// Consumed tells whether the Iterable has been traversed until its end
+// and is not a Rewinder, in which case reading it again produces nothing.
+//
+// The APIs returning an error, i.e. CollectTo, ToSlice, TryMap, ServeJSON,
+// ServeNDJSON and PostJSON, return a *MisuseError with ErrConsumed on a
+// consumed Iterable, whether the Iterator is strict or not.
+func (it *Iter) Consumed() bool {
+	return it.impl.spent()
+}
+
+// misuse records the violation err, see fail.
+func (it *iter) misuse(err error) {
+	it.fail(&MisuseError{Pos: it.pos, Item: it.item, Err: err})
+}
+
+// fail records err, only the first error is kept.
+func (it *iter) fail(err error) {
+	if it.err == nil {
+		it.err = err
+	}
+}
+
+// consumed tells whether reading it is a violation in strict mode.
+func (it *iter) consumed() bool {
+	if !it.strict || !it.spent() {
+		return false
+	}
+	it.misuse(ErrConsumed)
+	return true
+}
+
+// spent tells whether the Iterable is exhausted and is not a Rewinder.
+func (it *iter) spent() bool {
+	if !it.exhausted {
+		return false
+	}
+	_, ok := it.item.(Rewinder)
+	return !ok
+}
+
+// checkConsumed returns a *MisuseError with ErrConsumed if the Iterable
+// is consumed, strict mode or not, so that the APIs returning an error
+// don't silently produce an empty result.
+func (it *iter) checkConsumed() error {
+	if !it.spent() {
+		return nil
+	}
+	it.misuse(ErrConsumed)
+	return &MisuseError{Pos: it.pos, Item: it.item, Err: ErrConsumed}
+}
@@ -0,0 +1,131 @@
+package iter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// unfused is an Iterable which starts over after reporting its end.
+type unfused struct {
+	*IterStrings
+}
+
+func (u unfused) Next() (interface{}, bool) {
+	v, more := u.IterStrings.Next()
+	if !more {
+		u.IterStrings.Rewind()
+	}
+	return v, more
+}
+
+func TestStrict(t *testing.T) {
+	once := New(oneShot{FromStrings([]string{"a", "b"})}).Strict()
+	if got := once.Map(func(v interface{}) interface{} { return v }).Err(); got != nil {
+		t.Errorf("first use got error: %v", got)
+	}
+	var seen int
+	once.Each(func(interface{}) { seen++ })
+	if seen != 0 || !errors.Is(once.Err(), ErrConsumed) {
+		t.Errorf("reusing a consumed one-shot saw %d items with error: %v", seen, once.Err())
+	}
+
+	every := New(oneShot{FromStrings([]string{"a"})}).Strict().Every(func(_ int, v interface{}) interface{} { return v })
+	var me *MisuseError
+	if !errors.As(every.Err(), &me) || me.Err != ErrNotEnumerator {
+		t.Errorf("Every on a non Enumerator got error: %v", every.Err())
+	}
+
+	loop := New(unfused{FromStrings([]string{"a"})}).Strict()
+	loop.Advance(2)
+	if _, more := loop.Advance(1); more || !errors.Is(loop.Err(), ErrNotFused) {
+		t.Errorf("reading an unfused Iterable after its end got more %v with error: %v", more, loop.Err())
+	}
+
+	lax := New(oneShot{FromStrings([]string{"a"})})
+	lax.Count()
+	lax.Count()
+	if lax.Err() != nil {
+		t.Errorf("non strict Iterator got error: %v", lax.Err())
+	}
+}
+
+func TestConsumed(t *testing.T) {
+	once := New(oneShot{FromStrings([]string{"a"})})
+	var out []string
+	if err := once.CollectTo(&out); err != nil || !once.Consumed() {
+		t.Fatalf("first CollectTo got error: %v, consumed: %v", err, once.Consumed())
+	}
+	if err := once.CollectTo(&out); !errors.Is(err, ErrConsumed) || !once.Consumed() {
+		t.Errorf("CollectTo on a consumed Iterable got error: %v, consumed: %v", err, once.Consumed())
+	}
+	if err := once.ServeJSON(httptest.NewRecorder(), 0); !errors.Is(err, ErrConsumed) {
+		t.Errorf("ServeJSON on a consumed Iterable got error: %v", err)
+	}
+	if n, err := once.PostJSON(context.Background(), "http://127.0.0.1:1", PostOptions{}); !errors.Is(err, ErrConsumed) || n != 0 {
+		t.Errorf("PostJSON on a consumed Iterable got: %d, %v", n, err)
+	}
+	if newit, err := once.TryMap(func(v interface{}) (interface{}, error) { return v, nil }); !errors.Is(err, ErrConsumed) || newit.Count() != 0 {
+		t.Errorf("TryMap on a consumed Iterable got error: %v", err)
+	}
+	if len(out) != 1 {
+		t.Errorf("CollectTo on a consumed Iterable changed dst: %v", out)
+	}
+
+	rw := New(FromStrings([]string{"a"}))
+	rw.Count()
+	if err := rw.CollectTo(&out); err != nil || rw.Consumed() {
+		t.Errorf("CollectTo on a Rewinder got error: %v, consumed: %v", err, rw.Consumed())
+	}
+}
+
+// unmakeable is an Iterable whose New fails.
+type unmakeable struct {
+	Iterable
+}
+
+func (unmakeable) New() (Iterable, error) {
+	return nil, errors.New("out of handles")
+}
+
+func TestNewFailure(t *testing.T) {
+	it := New(unmakeable{FromStrings([]string{"a", "b"})}).
+		Filter(func(v interface{}) bool { return v.(string) == "b" }).
+		Map(func(v interface{}) interface{} { return v.(string) + "!" })
+	if err := it.Err(); err == nil || !strings.Contains(err.Error(), "out of handles") {
+		t.Errorf("adapters over a failing New got error: %v", err)
+	}
+	if got := fmt.Sprint(it.Collect()); got != "[b!]" {
+		t.Errorf("adapters over a failing New got: %s, want: [b!]", got)
+	}
+
+	from := New(unmakeable{FromStrings(nil)}).From(FromStrings([]string{"c"}), func(v interface{}) (interface{}, error) {
+		return v, nil
+	})
+	if from.Err() == nil || fmt.Sprint(from.Collect()) != "[c]" {
+		t.Errorf("From over a failing New got: %v with error: %v", from.Collect(), from.Err())
+	}
+	isA := func(v interface{}) bool { return v.(string) == "a" }
+	src := func() *Iter { return New(unmakeable{FromStrings([]string{"a", "b"})}) }
+	a, b := src().Partition(isA)
+	shards := src().ShardBy(2, func(v interface{}) interface{} { return v })
+	lcs := LCS(src(), New(FromStrings([]string{"b"})), func(x, y interface{}) bool { return x == y })
+	groups := src().GroupBy(func(v interface{}) interface{} { return v }).Collect().([]*Pair)
+	for name, it := range map[string]*Iter{"Partition": a, "Partition rest": b, "ShardBy": shards[1], "LCS": lcs, "GroupBy group": groups[0].Y.(*Iter)} {
+		if it.Err() == nil {
+			t.Errorf("%s over a failing New got no error", name)
+		}
+	}
+
+	run := NewPipeline().Filter(isA).Run(unmakeable{FromStrings([]string{"a", "b"})})
+	if got := fmt.Sprint(run.Collect()); got != "[a]" || run.Err() == nil {
+		t.Errorf("Collect of a Pipeline run over a failing New got: %s with error: %v", got, run.Err())
+	}
+	memo := src().Memoize()
+	if got := fmt.Sprint(memo.Collect()); got != "[a b]" || memo.Err() == nil {
+		t.Errorf("Collect of Memoize over a failing New got: %s with error: %v", got, memo.Err())
+	}
+}
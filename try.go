@@ -0,0 +1,59 @@
+package iter
+
+import "fmt"
+
+// MapError is the item TryMap has stopped at.
+type MapError struct {
+	// Index is the (0-based) position of the item in the source.
+	Index int
+	// Item is the failing item.
+	Item interface{}
+	// Err is the error returned for the item.
+	Err error
+}
+
+// Error implements the error interface for MapError.
+func (e *MapError) Error() string {
+	return fmt.Sprintf("iter: map item %d (%+v): %v", e.Index, e.Item, e.Err)
+}
+
+// Unwrap returns the error returned for the item.
+func (e *MapError) Unwrap() error {
+	return e.Err
+}
+
+// TryMap is like Map for a function which may fail: it stops at the first
+// item for which f returns a non-nil error and returns a *MapError telling
+// which one. The returned Iterator contains the items mapped until then.
+// Unlike Into, which drops the failing items, no failure goes unnoticed.
+// Same as CollectTo, TryMap returns a *MisuseError with ErrConsumed and
+// an empty Iterator on a consumed Iterable, see Consumed.
+//
+// Example:
+//   it := New(FromStrings([]string{"1", "x", "3"}))
+//   newit, err := it.TryMap(func(v interface{}) (interface{}, error) {
+//      return strconv.Atoi(v.(string))
+//   })
+//   produces a newit contains []interface{}{1}
+//   and err => iter: map item 1 (x): strconv.Atoi: parsing "x": invalid syntax
+func (it *Iter) TryMap(f ConvertFunc) (*Iter, error) {
+	newitem, _ := newElems()
+	if err := it.impl.checkConsumed(); err != nil {
+		return newFromImpl(it.impl.derive("TryMap", newitem, 0, 0)), err
+	}
+
+	var n int
+	for {
+		elm, more := it.impl.next()
+		if !more {
+			break
+		}
+		v, err := f(elm)
+		if err != nil {
+			return newFromImpl(it.impl.derive("TryMap", newitem, n+1, n)), &MapError{n, elm, err}
+		}
+		newitem.Add(v)
+		n++
+	}
+	return newFromImpl(it.impl.derive("TryMap", newitem, n, n)), nil
+}
@@ -0,0 +1,31 @@
+package iter
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func TestTryMap(t *testing.T) {
+	atoi := func(v interface{}) (interface{}, error) {
+		return strconv.Atoi(v.(string))
+	}
+
+	it, err := New(FromStrings([]string{"1", "2"})).TryMap(atoi)
+	if got := fmt.Sprint(it.Collect()); err != nil || got != "[1 2]" {
+		t.Errorf("TryMap got: %s with error: %v, want: [1 2]", got, err)
+	}
+
+	it, err = New(FromStrings([]string{"1", "x", "3"})).TryMap(atoi)
+	var me *MapError
+	if !errors.As(err, &me) || me.Index != 1 || me.Item != "x" || !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("TryMap got error: %v, want: a *MapError at item 1", err)
+	}
+	if got := fmt.Sprint(it.Collect()); got != "[1]" {
+		t.Errorf("TryMap got: %s until the error, want: [1]", got)
+	}
+	if got := it.Report().String(); got != "read 2, emitted 1; TryMap: 2 -> 1" {
+		t.Errorf("TryMap report got: %s", got)
+	}
+}
@@ -0,0 +1,69 @@
+package iter
+
+import "fmt"
+
+// ValidationError is an item rejected by Validate.
+type ValidationError struct {
+	// Index is the (0-based) position of the item in the source.
+	Index int
+	// Item is the rejected item.
+	Item interface{}
+	// Err is the error of the first rule rejecting the item.
+	Err error
+}
+
+// Error implements the error interface for ValidationError.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("item %d (%+v): %v", e.Index, e.Item, e.Err)
+}
+
+// Unwrap returns the error of the rule rejecting the item.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Validate checks every item of the Iterable against the rules, in order,
+// and splits them into two new Iterators: the first one contains the items
+// passing all the rules, the second one contains a *ValidationError for
+// every item rejected by a rule, e.g. to be sent to a dead-letter sink.
+//
+// A rule rejects an item by returning a non-nil error, the rules after it
+// are not run for that item.
+//
+// Example:
+//   valid, invalid := New(FromStrings([]string{"1", "x", "3"})).Validate(
+//      func(v interface{}) error {
+//         _, err := strconv.Atoi(v.(string))
+//         return err
+//      })
+//   valid contains []string{"1", "3"}
+//   invalid contains a *ValidationError{Index: 1, Item: "x", ...}
+func (it *Iter) Validate(rules ...func(interface{}) error) (*Iter, *Iter) {
+	valid := it.impl.newItem()
+	invalid, _ := newElems()
+
+	var read, emitted int
+	for {
+		elm, more := it.impl.next()
+		if !more {
+			break
+		}
+		if err := validate(elm, rules); err != nil {
+			invalid.Add(&ValidationError{Index: read, Item: elm, Err: err})
+		} else {
+			valid.Add(elm)
+			emitted++
+		}
+		read++
+	}
+	return it.impl.split("Validate", read, []Iterable{valid, invalid}, []int{emitted, read - emitted})
+}
+
+func validate(v interface{}, rules []func(interface{}) error) error {
+	for _, rule := range rules {
+		if err := rule(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
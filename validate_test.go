@@ -0,0 +1,41 @@
+package iter
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	errEmpty := errors.New("empty")
+	notEmpty := func(v interface{}) error {
+		if v.(string) == "" {
+			return errEmpty
+		}
+		return nil
+	}
+	isNumber := func(v interface{}) error {
+		_, err := strconv.Atoi(v.(string))
+		return err
+	}
+
+	valid, invalid := New(FromStrings([]string{"1", "x", "", "3"})).Validate(notEmpty, isNumber)
+
+	if got := fmt.Sprint(valid.Collect()); got != "[1 3]" {
+		t.Errorf("Validate valid items got: %s, want: [1 3]", got)
+	}
+
+	errs := invalid.Collect().([]interface{})
+	if len(errs) != 2 {
+		t.Fatalf("Validate invalid items got: %v, want 2 items", errs)
+	}
+	first, second := errs[0].(*ValidationError), errs[1].(*ValidationError)
+	var numErr *strconv.NumError
+	if first.Index != 1 || first.Item != "x" || !errors.As(first, &numErr) {
+		t.Errorf("Validate got first invalid item: %v", first)
+	}
+	if second.Index != 2 || !errors.Is(second, errEmpty) {
+		t.Errorf("Validate got second invalid item: %v, want the error of the first failing rule", second)
+	}
+}
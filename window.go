@@ -0,0 +1,203 @@
+package iter
+
+import (
+	"container/heap"
+	"fmt"
+	"time"
+)
+
+// Sessions groups consecutive items into sessions separated by gaps of
+// inactivity and returns a new Iterator whose items are the sessions,
+// every one being an *Iter of the items of that session.
+//
+// ts returns the timestamp of an item. A new session starts whenever
+// the timestamp of an item is more than gap after the one of the previous
+// item. The items are expected to be ordered by their timestamps, see
+// Reorder for slightly out-of-order inputs.
+//
+// Example:
+//   it := New(events) // at 10:00, 10:01, 10:30, 10:31
+//   it.Sessions(10*time.Minute, ts)
+//   produces 2 sessions: [10:00 10:01] and [10:30 10:31]
+func (it *Iter) Sessions(gap time.Duration, ts func(interface{}) time.Time) *Iter {
+	sessions, _ := newElems()
+
+	var cur Iterable
+	var last time.Time
+	var read int
+	for {
+		elm, more := it.impl.next()
+		if !more {
+			break
+		}
+		read++
+		t := ts(elm)
+		if cur == nil || t.Sub(last) > gap {
+			cur = it.impl.newItem()
+			sessions.Add(it.impl.inner(cur))
+		}
+		cur.Add(elm)
+		last = t
+	}
+	return newFromImpl(it.impl.derive("Sessions", sessions, read, sessions.(Lener).Len()))
+}
+
+// Windows returns a new Iterator whose items are the overlapping windows
+// of n consecutive items of the Iterable, every one being an *Iter of
+// these items, e.g. for moving averages. An Iterable with less than n
+// items produces no window. Windows panics if n isn't positive.
+//
+// Example:
+//   it := New(FromStrings([]string{"a", "b", "c", "d"}))
+//   newit := it.Windows(3)
+//   produces 2 windows: [a b c] and [b c d]
+func (it *Iter) Windows(n int) *Iter {
+	return newFromImpl(it.impl.windows("Windows", n, 1))
+}
+
+// WindowsStep is like Windows but starts a window every step items,
+// so that the windows don't overlap when step is n, and some items are
+// skipped when step is greater than n.
+//
+// Example:
+//   it := New(FromStrings([]string{"a", "b", "c", "d", "e"}))
+//   newit := it.WindowsStep(3, 2)
+//   produces 2 windows: [a b c] and [c d e]
+func (it *Iter) WindowsStep(n, step int) *Iter {
+	return newFromImpl(it.impl.windows("WindowsStep", n, step))
+}
+
+func (it *iter) windows(stage string, n, step int) *iter {
+	if n <= 0 || step <= 0 {
+		panic(fmt.Sprintf("iter: %s needs a positive size and step, got %d and %d", stage, n, step))
+	}
+	windows, _ := newElems()
+
+	// last holds the last n items read, skip counts the items to read
+	// before the next window starts.
+	var last []interface{}
+	var read, skip int
+	for {
+		elm, more := it.next()
+		if !more {
+			break
+		}
+		read++
+		last = append(last, elm)
+		if len(last) > n {
+			last = last[1:]
+		}
+		if skip > 0 {
+			skip--
+		}
+		if len(last) < n || skip > 0 {
+			continue
+		}
+		w := it.newItem()
+		for _, v := range last {
+			w.Add(v)
+		}
+		windows.Add(it.inner(w))
+		skip = step
+	}
+	return it.derive(stage, windows, read, windows.(Lener).Len())
+}
+
+// Reorder emits the items of a slightly out-of-order stream in the order
+// of their timestamps, e.g. before a windowed aggregation.
+//
+// Reorder buffers the items and tracks a watermark, which is the latest
+// timestamp seen so far minus maxLateness. A buffered item is emitted once
+// its timestamp isn't after the watermark, so the memory usage is bounded
+// by the number of items arriving within maxLateness. Items with the same
+// timestamp keep their original order.
+//
+// An item arriving later than maxLateness, i.e. its timestamp is before
+// an already emitted one, can't be put back in order. It is emitted as
+// soon as possible instead of being dropped.
+//
+// Reorder is lazy. The returned Iterable can only be traversed once and
+// doesn't accept Add.
+func (it *Iter) Reorder(maxLateness time.Duration, ts func(interface{}) time.Time) *Iter {
+	return New(&reordered{src: it.impl.item, lateness: maxLateness, ts: ts})
+}
+
+// reordered is the lazy Iterable behind Reorder.
+type reordered struct {
+	src      Iterable
+	lateness time.Duration
+	ts       func(interface{}) time.Time
+
+	buf       stamped
+	seq       int
+	latest    time.Time
+	watermark time.Time
+	done      bool
+}
+
+// Err returns the error of the source, if any.
+func (r *reordered) Err() error {
+	return errOf(r.src)
+}
+
+func (r *reordered) New() (Iterable, error) {
+	return r.src.New()
+}
+
+func (*reordered) Add(interface{}) {
+	panic("iter: Add is not supported by a reordered Iterable")
+}
+
+func (r *reordered) Next() (interface{}, bool) {
+	for !r.done {
+		if r.buf.Len() > 0 && !r.buf[0].t.After(r.watermark) {
+			break
+		}
+		v, more := r.src.Next()
+		if !more {
+			r.done = true
+			break
+		}
+		t := r.ts(v)
+		heap.Push(&r.buf, stampedItem{v, t, r.seq})
+		r.seq++
+		if t.After(r.latest) || r.seq == 1 {
+			r.latest = t
+			r.watermark = t.Add(-r.lateness)
+		}
+	}
+
+	if r.buf.Len() == 0 {
+		return nil, false
+	}
+	return heap.Pop(&r.buf).(stampedItem).v, true
+}
+
+type stampedItem struct {
+	v   interface{}
+	t   time.Time
+	seq int
+}
+
+// stamped implements heap.Interface ordered by timestamps.
+type stamped []stampedItem
+
+func (s stamped) Len() int { return len(s) }
+
+func (s stamped) Less(i, j int) bool {
+	if !s[i].t.Equal(s[j].t) {
+		return s[i].t.Before(s[j].t)
+	}
+	return s[i].seq < s[j].seq
+}
+
+func (s stamped) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s *stamped) Push(x interface{}) { *s = append(*s, x.(stampedItem)) }
+
+func (s *stamped) Pop() interface{} {
+	old := *s
+	last := old[len(old)-1]
+	*s = old[:len(old)-1]
+	return last
+}
@@ -0,0 +1,95 @@
+package iter
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// stamps turns "hh:mm" strings into their time of the day.
+func stamps(v interface{}) time.Time {
+	t, err := time.Parse("15:04", v.(string))
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestSessions(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   []string
+		want string
+	}{
+		{"two", []string{"10:00", "10:05", "10:30", "10:31"}, "[[10:00 10:05] [10:30 10:31]]"},
+		{"gap-inclusive", []string{"10:00", "10:10", "10:21"}, "[[10:00 10:10] [10:21]]"},
+		{"one", []string{"10:00"}, "[[10:00]]"},
+		{"empty", nil, "[]"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var got []interface{}
+			New(FromStrings(tc.in)).Sessions(10*time.Minute, stamps).Each(func(v interface{}) {
+				got = append(got, v.(*Iter).Collect())
+			})
+			if fmt.Sprint(got) != tc.want {
+				t.Errorf("Sessions got: %v, want: %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWindows(t *testing.T) {
+	in := []string{"a", "b", "c", "d", "e"}
+	tests := []struct {
+		desc    string
+		n, step int
+		want    string
+	}{
+		{"sliding", 3, 1, "[[a b c] [b c d] [c d e]]"},
+		{"step", 3, 2, "[[a b c] [c d e]]"},
+		{"tumbling", 2, 2, "[[a b] [c d]]"},
+		{"skipping", 1, 3, "[[a] [d]]"},
+		{"too-short", 6, 1, "[]"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var got []interface{}
+			New(FromStrings(in)).WindowsStep(tc.n, tc.step).Each(func(v interface{}) {
+				got = append(got, v.(*Iter).Collect())
+			})
+			if fmt.Sprint(got) != tc.want {
+				t.Errorf("WindowsStep(%d, %d) got: %v, want: %s", tc.n, tc.step, got, tc.want)
+			}
+		})
+	}
+
+	if got := New(FromStrings(in)).Windows(4).Count(); got != 2 {
+		t.Errorf("Windows(4) got %d windows, want: 2", got)
+	}
+}
+
+func TestReorder(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   []string
+		want string
+	}{
+		{"in-order", []string{"10:00", "10:01", "10:02"}, "[10:00 10:01 10:02]"},
+		{"late-within", []string{"10:02", "10:00", "10:05", "10:03", "10:04"}, "[10:00 10:02 10:03 10:04 10:05]"},
+		{"too-late", []string{"10:00", "10:10", "10:01", "10:11"}, "[10:00 10:01 10:10 10:11]"},
+		{"way-too-late", []string{"10:00", "10:10", "10:20", "10:01"}, "[10:00 10:10 10:01 10:20]"},
+		{"empty", nil, "[]"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := New(FromStrings(tc.in)).Reorder(5*time.Minute, stamps).impl.slice()
+			if fmt.Sprint(got) != tc.want {
+				t.Errorf("Reorder(%v) got: %v, want: %s", tc.in, got, tc.want)
+			}
+		})
+	}
+}